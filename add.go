@@ -0,0 +1,74 @@
+package ecs
+
+// Add attaches a component to an entity, moving it to the compound matching its new set of components.
+// An existing T component is overwritten. An entity that does not exist is created with only T.
+func Add[T any, ID Int](storage *Storage[ID], id ID, v T) {
+	storage.wlock()
+	defer storage.unlock()
+	add(storage, id, v)
+}
+
+// Unset detaches the T component from an entity. The entity itself is kept, even without components.
+func Unset[T any, ID Int](storage *Storage[ID], id ID) {
+	storage.wlock()
+	defer storage.unlock()
+	unset[T](storage, id)
+}
+
+func add[T any, ID Int](storage *Storage[ID], id ID, v T) {
+	if storage.allocator.stale(id) {
+		return
+	}
+	component := componentEnsure[T](storage)
+	hash := componentHash(storage, component, v)
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		storage.spawnEmpty(id)
+		entity = storage.Entitys[id]
+	}
+	src := storage.Compounds[entity.Compound]
+	idx, existed := compoundColumn(src, component)
+	hookWrite(storage, component, id, v, existed)
+	if existed && src.Components[idx].Hash == hash {
+		compoundSet(src, component, entity.Row, v, storage.tick.Load())
+		return
+	}
+	components := []int{component}
+	hashes := []int{hash}
+	for _, v := range src.Components {
+		if v.ID != component {
+			components = append(components, v.ID)
+			hashes = append(hashes, v.Hash)
+		}
+	}
+	entity = storage.entityMove(id, entity, storage.compoundEnsure(components, hashes), component)
+	tick := storage.tick.Load()
+	compoundAppend(storage.Compounds[entity.Compound], component, v, tick, tick)
+}
+
+func unset[T any, ID Int](storage *Storage[ID], id ID) {
+	if component, ok := storage.getComponent(typeOf[T]()); ok {
+		storage.unsetComponent(id, component)
+	}
+}
+
+func (storage *Storage[ID]) unsetComponent(id ID, component int) {
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return
+	}
+	src := storage.Compounds[entity.Compound]
+	idx, ok := compoundColumn(src, component)
+	if !ok {
+		return
+	}
+	storage.hookRemove(src.Components[idx], id, entity.Row)
+	var components, hashes []int
+	for _, v := range src.Components {
+		if v.ID != component {
+			components = append(components, v.ID)
+			hashes = append(hashes, v.Hash)
+		}
+	}
+	storage.entityMove(id, entity, storage.compoundEnsure(components, hashes), component)
+}
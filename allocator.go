@@ -0,0 +1,123 @@
+package ecs
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// allocator hands out entity handles packing an index and a generation into ID.
+// The lower bits hold the index, the upper quarter of the usable bits the generation.
+type allocator[ID Int] struct {
+	lock        sync.Mutex
+	used        atomic.Bool
+	bits        uint // Usable bits of ID, without the sign bit
+	indexBits   uint
+	generations []ID
+	alive       []bool
+	free        []ID
+}
+
+func newAllocator[ID Int]() *allocator[ID] {
+	bits := uint(unsafe.Sizeof(ID(0))) * 8
+	if ^ID(0) < 0 {
+		bits-- // Keep the sign bit clear for signed IDs
+	}
+	return &allocator[ID]{bits: bits, indexBits: bits - bits/4}
+}
+
+func (a *allocator[ID]) split(id ID) (index, generation ID) {
+	return id & (ID(1)<<a.indexBits - 1), id >> a.indexBits
+}
+
+func (a *allocator[ID]) spawn() ID {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.used.Store(true)
+	var index ID
+	if len(a.free) > 0 {
+		index = a.free[len(a.free)-1]
+		a.free = a.free[:len(a.free)-1]
+	} else {
+		index = ID(len(a.generations))
+		if index>>a.indexBits != 0 {
+			panic("ecs: entity handle index space exhausted")
+		}
+		a.generations = append(a.generations, 0)
+		a.alive = append(a.alive, false)
+	}
+	a.alive[index] = true
+	return a.generations[index]<<a.indexBits | index
+}
+
+// release frees the slot of a live handle and bumps its generation.
+func (a *allocator[ID]) release(id ID) {
+	if !a.used.Load() {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if !a.aliveLocked(id) {
+		return
+	}
+	index, generation := a.split(id)
+	a.alive[index] = false
+	a.generations[index] = (generation + 1) & (ID(1)<<(a.bits-a.indexBits) - 1)
+	a.free = append(a.free, index)
+}
+
+// stale reports whether id is not a live handle. Always false until the first Spawn.
+func (a *allocator[ID]) stale(id ID) bool {
+	if !a.used.Load() {
+		return false
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return !a.aliveLocked(id)
+}
+
+func (a *allocator[ID]) aliveLocked(id ID) bool {
+	index, generation := a.split(id)
+	return index < ID(len(a.generations)) && a.generations[index] == generation && a.alive[index]
+}
+
+// Spawn allocates a new entity handle and creates the entity without components.
+// Once Spawn has been used, Set, Add and Remove ignore IDs that are not live handles,
+// so a handle kept after Remove can never alias the entity that reuses its slot.
+// Handles from Spawn and caller-chosen IDs should not be mixed in one storage.
+// Handles equal to the ID of an existing entity are skipped and left to that entity.
+func (storage *Storage[ID]) Spawn() ID {
+	storage.wlock()
+	defer storage.unlock()
+	id := storage.spawnHandle()
+	storage.spawnEmpty(id)
+	return id
+}
+
+// spawnHandle allocates a handle not used by an existing entity. The storage must be locked.
+func (storage *Storage[ID]) spawnHandle() ID {
+	id := storage.allocator.spawn()
+	for {
+		if _, taken := storage.Entitys[id]; !taken {
+			return id
+		}
+		id = storage.allocator.spawn()
+	}
+}
+
+// Alive reports whether id is a live handle returned by Spawn.
+func (storage *Storage[ID]) Alive(id ID) bool {
+	return storage.allocator.used.Load() && !storage.allocator.stale(id)
+}
+
+// spawnEmpty creates an entity without components, dropping the components of an entity that
+// already has the ID, as Commands.Spawn can not skip IDs taken before the commands are applied.
+func (storage *Storage[ID]) spawnEmpty(id ID) {
+	compoundIdx := storage.compoundEnsure(nil, nil)
+	compound := storage.Compounds[compoundIdx]
+	if entity, ok := storage.Entitys[id]; ok {
+		storage.hookReplace(id, entity, nil)
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+}
@@ -0,0 +1,104 @@
+package ecs
+
+import (
+	"slices"
+	"sync"
+)
+
+// queryMatch is a compound and the column of each query component in it, -1 when missing.
+type queryMatch struct {
+	compound int
+	columns  []int
+}
+
+// queryCache remembers the compounds a query can visit. Compounds are never removed or
+// reordered, so only compounds created since the last lookup need to be inspected.
+type queryCache struct {
+	lock sync.Mutex
+	seen int
+	all  []queryMatch // Every compound, used when a component is optional
+	full []queryMatch // Compounds that have every query component
+}
+
+func (storage *Storage[ID]) queryCacheMatches(cache *queryCache, components []int, optional []bool) []queryMatch {
+	if !storage.unlocked {
+		cache.lock.Lock()
+		defer cache.lock.Unlock()
+	}
+	compounds := storage.Compounds
+	for ; cache.seen < len(compounds); cache.seen++ {
+		match := queryMatch{compound: cache.seen, columns: make([]int, len(components))}
+		full := true
+		for i, component := range components {
+			column, ok := compoundColumn(compounds[cache.seen], component)
+			if !ok {
+				column = -1
+				full = false
+			}
+			match.columns[i] = column
+		}
+		cache.all = append(cache.all, match)
+		if full {
+			cache.full = append(cache.full, match)
+		}
+	}
+	if slices.Contains(optional, true) {
+		return cache.all
+	}
+	return cache.full
+}
+
+// rlockClean read locks the storage after purging the removed rows of the compounds a query matches.
+// Rows removed between purging and locking are left as tombstones, unless strict is set,
+// in which case it retries until the matched compounds are clean.
+func (storage *Storage[ID]) rlockClean(cache *queryCache, components []int, optional []bool, strict bool) []queryMatch {
+	storage.rlock()
+	matches := storage.queryCacheMatches(cache, components, optional)
+	for storage.matchesDirty(matches) {
+		storage.runlock()
+		storage.wlock()
+		storage.matchesClean(matches)
+		storage.unlock()
+		storage.rlock()
+		matches = storage.queryCacheMatches(cache, components, optional)
+		if !strict {
+			break
+		}
+	}
+	return matches
+}
+
+// matchesDirty reports whether any of the compounds has rows of removed entities.
+func (storage *Storage[ID]) matchesDirty(matches []queryMatch) bool {
+	for _, match := range matches {
+		if storage.Compounds[match.compound].Removed != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (storage *Storage[ID]) matchesClean(matches []queryMatch) {
+	for _, match := range matches {
+		storage.compoundClean(match.compound)
+	}
+}
+
+func columnData[T any, ID Int](compound *Compound[ID], column int) []T {
+	if column < 0 {
+		return nil
+	}
+	return compound.Components[column].Data.(*slice[T]).Data
+}
+
+func hashMatch(components []CompoundComponent, hash *ComponentHash) bool {
+	if hash == nil {
+		return true
+	}
+	for _, component := range components {
+		if component.ID == hash.ID && component.Hash != hash.Hash {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package ecs
+
+import "sync/atomic"
+
+// Tick ends the current world tick and returns it. Every change made afterwards is stamped with a
+// later tick, so using the returned value as Since on the next run selects exactly the changes in between.
+func (storage *Storage[ID]) Tick() uint64 {
+	return storage.tick.Add(1) - 1
+}
+
+// MarkChanged stamps the T component of an entity as changed in the current tick.
+// Use the Mark query option instead from inside Each.
+func MarkChanged[T any, ID Int](storage *Storage[ID], id ID) {
+	storage.rlock()
+	defer storage.runlock()
+	component, ok := storage.getComponent(typeOf[T]())
+	if !ok {
+		return
+	}
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return
+	}
+	compound := storage.Compounds[entity.Compound]
+	if idx, ok := compoundColumn(compound, component); ok {
+		atomic.StoreUint64(&compound.Components[idx].Changed[entity.Row], storage.tick.Load())
+	}
+}
+
+// changeFilter is the resolved form of the Added, Changed and Since query options.
+type changeFilter struct {
+	active  bool
+	never   bool
+	since   uint64
+	added   []int
+	changed []int
+	// Tick columns of the compound last bound
+	addedTicks   [][]uint64
+	changedTicks [][]uint64
+}
+
+func (storage *Storage[ID]) changeResolve(added, changed []ComponentType, since uint64) changeFilter {
+	var filter changeFilter
+	if len(added) == 0 && len(changed) == 0 {
+		return filter
+	}
+	filter.active = true
+	filter.since = since
+	for _, t := range added {
+		id, ok := storage.getComponent(t.t)
+		if !ok {
+			filter.never = true
+			return filter
+		}
+		filter.added = append(filter.added, id)
+	}
+	for _, t := range changed {
+		id, ok := storage.getComponent(t.t)
+		if !ok {
+			filter.never = true
+			return filter
+		}
+		filter.changed = append(filter.changed, id)
+	}
+	return filter
+}
+
+// bind selects the tick columns of a compound, returning false when it lacks a filtered component.
+func (filter *changeFilter) bind(components []CompoundComponent) bool {
+	if !filter.active {
+		return true
+	}
+	if filter.never {
+		return false
+	}
+	filter.addedTicks = filter.addedTicks[:0]
+	filter.changedTicks = filter.changedTicks[:0]
+	for _, id := range filter.added {
+		idx, ok := componentsColumn(components, id)
+		if !ok {
+			return false
+		}
+		filter.addedTicks = append(filter.addedTicks, components[idx].Added)
+	}
+	for _, id := range filter.changed {
+		idx, ok := componentsColumn(components, id)
+		if !ok {
+			return false
+		}
+		filter.changedTicks = append(filter.changedTicks, components[idx].Changed)
+	}
+	return true
+}
+
+// fork returns a copy that can be bound independently, for use from another goroutine.
+func (filter changeFilter) fork() changeFilter {
+	filter.addedTicks, filter.changedTicks = nil, nil
+	return filter
+}
+
+// row reports whether a row of the bound compound passes the filter.
+func (filter *changeFilter) row(idx int) bool {
+	if !filter.active {
+		return true
+	}
+	for _, ticks := range filter.addedTicks {
+		if atomic.LoadUint64(&ticks[idx]) <= filter.since {
+			return false
+		}
+	}
+	for _, ticks := range filter.changedTicks {
+		if atomic.LoadUint64(&ticks[idx]) <= filter.since {
+			return false
+		}
+	}
+	return true
+}
+
+// markRow stamps the query components flagged in mark as changed.
+func markRow[ID Int](compound *Compound[ID], columns []int, mark []bool, row int, tick uint64) {
+	for i, column := range columns {
+		if mark[i] && column >= 0 {
+			atomic.StoreUint64(&compound.Components[column].Changed[row], tick)
+		}
+	}
+}
+
+func componentsColumn(components []CompoundComponent, id int) (int, bool) {
+	for idx, component := range components {
+		if component.ID == id {
+			return idx, true
+		}
+	}
+	return 0, false
+}
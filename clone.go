@@ -0,0 +1,95 @@
+package ecs
+
+import "unsafe"
+
+// Clone copies every component of an entity to newID, replacing the components newID had.
+// It fails when the entity does not exist or newID is not a live handle.
+func Clone[ID Int](storage *Storage[ID], id, newID ID) bool {
+	storage.wlock()
+	defer storage.unlock()
+	entity, ok := storage.Entitys[id]
+	if !ok || storage.allocator.stale(newID) {
+		return false
+	}
+	storage.setValues(newID, storage.rowValues(entity))
+	return true
+}
+
+// Transfer moves an entity with all of its components from src to dst, keeping its ID.
+// It fails when the entity does not exist in src, or when dst already has an entity with the ID
+// or uses Spawn and the ID is not a live handle of it.
+func Transfer[ID Int](src, dst *Storage[ID], id ID) bool {
+	if src == dst {
+		src.rlock()
+		defer src.runlock()
+		_, ok := src.Entitys[id]
+		return ok
+	}
+	// Lock both storages in address order, so opposite Transfers can not deadlock
+	first, second := src, dst
+	if uintptr(unsafe.Pointer(dst)) < uintptr(unsafe.Pointer(src)) {
+		first, second = dst, src
+	}
+	first.wlock()
+	defer first.unlock()
+	second.wlock()
+	defer second.unlock()
+	entity, ok := src.Entitys[id]
+	if !ok || dst.allocator.stale(id) {
+		return false
+	}
+	if _, taken := dst.Entitys[id]; taken {
+		return false
+	}
+	values := src.rowValues(entity)
+	src.remove(id)
+	dst.setValues(id, values)
+	return true
+}
+
+// rowValues copies the components of an entity out of its compound.
+func (storage *Storage[ID]) rowValues(entity Entity) []PrefabValue {
+	compound := storage.Compounds[entity.Compound]
+	values := make([]PrefabValue, len(compound.Components))
+	for idx, column := range compound.Components {
+		data := column.Data.empty()
+		data.appendFrom(column.Data, entity.Row)
+		values[idx] = PrefabValue{t: storage.Components[column.ID].Type, hash: column.Hash, data: data}
+	}
+	return values
+}
+
+// setValues gives an entity exactly the given components, the type-erased form of SetN.
+func (storage *Storage[ID]) setValues(id ID, values []PrefabValue) {
+	components := make([]int, len(values))
+	hashes := make([]int, len(values))
+	for idx, value := range values {
+		components[idx] = storage.componentEnsureType(value.t)
+		hashes[idx] = value.hash
+	}
+	compoundIdx := storage.compoundEnsure(components, hashes)
+	compound := storage.Compounds[compoundIdx]
+	tick := storage.tick.Load()
+	added := make([]uint64, len(values))
+	for idx := range added {
+		added[idx] = tick
+	}
+	var existed uint64
+	if entity, ok := storage.Entitys[id]; ok {
+		existed = storage.hookReplace(id, entity, components)
+		storage.rowAdded(entity, components, added)
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	for idx, value := range values {
+		cidx, _ := compoundColumn(compound, components[idx])
+		column := &compound.Components[cidx]
+		if column.Data == nil {
+			column.Data = value.data.empty()
+		}
+		column.Data.appendFrom(value.data, 0)
+		column.Added = append(column.Added, added[idx])
+		column.Changed = append(column.Changed, tick)
+		hookWrite(storage, components[idx], id, value.data.get(0), existed&(1<<idx) != 0)
+	}
+}
@@ -24,12 +24,24 @@ func main() {
 	}
 	buffer := &bytes.Buffer{}
 	buffer.WriteString("// Code generated by generate command. DO NOT EDIT.\n")
-	buffer.WriteString(fmt.Sprintf("package %s\n\nimport (\n\"fmt\"\n)\n", pkg))
+	buffer.WriteString(fmt.Sprintf("package %s\n\nimport (\n\"fmt\"\n\"iter\"\n\"slices\"\n)\n", pkg))
 
 	for i := 0; i < *depth; i++ {
 		buildSetFunc(buffer, i+1)
 	}
 
+	for i := 0; i < *depth; i++ {
+		buildSetBatchFunc(buffer, i+1)
+	}
+
+	for i := 0; i < *depth; i++ {
+		buildReserveFunc(buffer, i+1)
+	}
+
+	for i := 0; i < *depth; i++ {
+		buildGetFunc(buffer, i+1)
+	}
+
 	for i := 0; i < *depth; i++ {
 		buildQueryType(buffer, i+1)
 	}
@@ -42,6 +54,22 @@ func main() {
 		buildQueryEachFunc(buffer, i+1)
 	}
 
+	for i := 0; i < *depth; i++ {
+		buildQueryEachCmdFunc(buffer, i+1)
+	}
+
+	for i := 0; i < *depth; i++ {
+		buildQueryAllFunc(buffer, i+1)
+	}
+
+	for i := 0; i < *depth; i++ {
+		buildQueryParEachFunc(buffer, i+1)
+	}
+
+	for i := 0; i < *depth; i++ {
+		buildQueryEachChunkFunc(buffer, i+1)
+	}
+
 	fmt, err := format.Source(buffer.Bytes())
 	if err != nil {
 		panic(err)
@@ -53,19 +81,35 @@ func main() {
 }
 
 // func Set1[ID Int, T1 any](storage *Storage[ID], id ID, v1 T1) {
-//  storage.lock.Lock()
-//  defer storage.lock.Unlock()
-// 	components := []int{storage.componentEnsure(v1)}
-// 	hashes := []int{componentHash(v1)}
-// 	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-// 	storage.Entitys[id] = entity
-// 	compound := storage.Compounds[entity.Compound]
-// 	compound.Entitys = append(compound.Entitys, id)
-// if compound.Components[0].Data == nil {
-// 	compound.Components[0].Data = slice[T1]{data: []T1{v1}}
-// 	return
+// 	storage.wlock()
+// 	defer storage.unlock()
+// 	set1(storage, id, v1)
 // }
-// compound.Components[0].Data.(slice[T1]).append(v1)
+//
+// func set1[ID Int, T1 any](storage *Storage[ID], id ID, v1 T1) {
+// 	if storage.allocator.stale(id) {
+// 		return
+// 	}
+// 	tick := storage.tick.Load()
+// 	components := [1]int{componentEnsure[T1](storage)}
+// 	hashes := [1]int{componentHash(storage, components[0], v1)}
+// 	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+// 	compound := storage.Compounds[compoundIdx]
+// 	var existed uint64
+// 	added := [1]uint64{tick}
+// 	if entity, ok := storage.Entitys[id]; ok {
+// 		if entity.Compound == compoundIdx {
+// 			compoundSet(compound, components[0], entity.Row, v1, tick)
+// 			hookWrite(storage, components[0], id, v1, true)
+// 			return
+// 		}
+// 		existed = storage.hookReplace(id, entity, components[:])
+// 		storage.rowAdded(entity, components[:], added[:])
+// 		storage.compoundRemoveRow(entity.Compound, entity.Row)
+// 	}
+// 	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+// 	compoundAppend(compound, components[0], v1, added[0], tick)
+// 	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
 // }
 
 func buildSetFunc(buffer *bytes.Buffer, depth int) {
@@ -73,33 +117,271 @@ func buildSetFunc(buffer *bytes.Buffer, depth int) {
 	var genericReturns string
 	var ensures []string
 	var hashes []string
-	var valueSetNil string
 	var valueSet string
+	var valueAppend string
+	var addedTicks []string
 	for i := 1; i <= depth; i++ {
 		genericParams += fmt.Sprintf(",T%d", i)
 		genericReturns += fmt.Sprintf(",v%d T%d", i, i)
-		ensures = append(ensures, fmt.Sprintf("storage.componentEnsure(v%d)", i))
-		hashes = append(hashes, fmt.Sprintf("componentHash(v%d)", i))
-		valueSetNil += fmt.Sprintf("compound.Components[%d].Data = &slice[T%d]{Data: []T%d{v%d}}\n", i-1, i, i, i)
-		valueSet += fmt.Sprintf("compound.Components[%d].Data.(*slice[T%d]).append(v%d)\n", i-1, i, i)
+		ensures = append(ensures, fmt.Sprintf("componentEnsure[T%d](storage)", i))
+		hashes = append(hashes, fmt.Sprintf("componentHash(storage, components[%d], v%d)", i-1, i))
+		valueSet += fmt.Sprintf("compoundSet(compound, components[%d], entity.Row, v%d, tick)\nhookWrite(storage, components[%d], id, v%d, true)\n", i-1, i, i-1, i)
+		valueAppend += fmt.Sprintf("compoundAppend(compound, components[%d], v%d, added[%d], tick)\nhookWrite(storage, components[%d], id, v%d, existed&(1<<%d) != 0)\n", i-1, i, i-1, i-1, i, i-1)
+		addedTicks = append(addedTicks, "tick")
+	}
+	var values string
+	for i := 1; i <= depth; i++ {
+		values += fmt.Sprintf(", v%d", i)
 	}
 	buffer.WriteString(fmt.Sprintf(`
 func Set%d[ID Int%s any](storage *Storage[ID], id ID%s) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{%s}
-	hashes := []int{%s}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		%s
+	storage.wlock()
+	defer storage.unlock()
+	set%d(storage, id%s)
+}
+
+func set%d[ID Int%s any](storage *Storage[ID], id ID%s) {
+	if storage.allocator.stale(id) {
 		return
 	}
+	tick := storage.tick.Load()
+	components := [%d]int{%s}
+	hashes := [%d]int{%s}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [%d]uint64{%s}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			%s
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
 	%s
 }
-`, depth, genericParams, genericReturns, strings.Join(ensures, ","), strings.Join(hashes, ","), valueSetNil, valueSet))
+
+// CommandSet%d records a Set%d to run when the commands are applied.
+func CommandSet%d[ID Int%s any](cmd *Commands[ID], id ID%s) {
+	cmd.record(func(storage *Storage[ID]) { set%d(storage, id%s) })
+}
+`, depth, genericParams, genericReturns, depth, values,
+		depth, genericParams, genericReturns, depth, strings.Join(ensures, ","), depth, strings.Join(hashes, ","), depth, strings.Join(addedTicks, ", "), valueSet, valueAppend,
+		depth, depth, depth, genericParams, genericReturns, depth, values))
+}
+
+// func SetBatch1[ID Int, T1 any](storage *Storage[ID], ids []ID, v1s []T1) {
+// 	if len(v1s) != len(ids) {
+// 		panic("ecs: SetBatch1 needs one value per id")
+// 	}
+// 	storage.wlock()
+// 	defer storage.unlock()
+// 	setBatch1(storage, ids, v1s)
+// }
+//
+// func SpawnBatch1[ID Int, T1 any](storage *Storage[ID], v1s []T1) []ID {
+// 	storage.wlock()
+// 	defer storage.unlock()
+// 	ids := make([]ID, len(v1s))
+// 	for i := range ids {
+// 		ids[i] = storage.spawnHandle()
+// 	}
+// 	setBatch1(storage, ids, v1s)
+// 	return ids
+// }
+//
+// func setBatch1[ID Int, T1 any](storage *Storage[ID], ids []ID, v1s []T1) {
+// 	components := [1]int{componentEnsure[T1](storage)}
+// 	if storage.componentsHashable(components[:]) {
+// 		for i, id := range ids {
+// 			set1(storage, id, v1s[i])
+// 		}
+// 		return
+// 	}
+// 	tick := storage.tick.Load()
+// 	var hashes [1]int
+// 	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+// 	compound := storage.Compounds[compoundIdx]
+// 	compound.growRows(len(ids))
+// 	compoundGrow[T1](compound, components[0], len(ids))
+// 	for i, id := range ids {
+// 		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+// 			set1(storage, id, v1s[i])
+// 			continue
+// 		}
+// 		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+// 		compoundAppend(compound, components[0], v1s[i], tick, tick)
+// 		hookWrite(storage, components[0], id, v1s[i], false)
+// 	}
+// }
+
+func buildSetBatchFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams string
+	var params string
+	var values string
+	var checks string
+	var ensures []string
+	var grows string
+	var appends string
+	for i := 1; i <= depth; i++ {
+		genericParams += fmt.Sprintf(",T%d", i)
+		params += fmt.Sprintf(", v%ds []T%d", i, i)
+		values += fmt.Sprintf(", v%ds[i]", i)
+		if i > 1 {
+			checks += fmt.Sprintf(" || len(v%ds) != len(v1s)", i)
+		}
+		ensures = append(ensures, fmt.Sprintf("componentEnsure[T%d](storage)", i))
+		grows += fmt.Sprintf("compoundGrow[T%d](compound, components[%d], len(ids))\n", i, i-1)
+		appends += fmt.Sprintf("compoundAppend(compound, components[%d], v%ds[i], tick, tick)\nhookWrite(storage, components[%d], id, v%ds[i], false)\n", i-1, i, i-1, i)
+	}
+	var names string
+	for i := 1; i <= depth; i++ {
+		names += fmt.Sprintf(", v%ds", i)
+	}
+	var spawnCheck string
+	if depth > 1 {
+		spawnCheck = fmt.Sprintf("if %s {\npanic(\"ecs: SpawnBatch%d needs slices of equal length\")\n}\n", strings.TrimPrefix(checks, " || "), depth)
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// SetBatch%d sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch%d[ID Int%s any](storage *Storage[ID], ids []ID%s) {
+	if len(v1s) != len(ids)%s {
+		panic("ecs: SetBatch%d needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch%d(storage, ids%s)
+}
+
+// SpawnBatch%d allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch%d[ID Int%s any](storage *Storage[ID]%s) []ID {
+	%sstorage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch%d(storage, ids%s)
+	return ids
+}
+
+func setBatch%d[ID Int%s any](storage *Storage[ID], ids []ID%s) {
+	components := [%d]int{%s}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set%d(storage, id%s)
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [%d]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	%s
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set%d(storage, id%s)
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		%s
+	}
+}
+`, depth, depth, genericParams, params, checks, depth, depth, names,
+		depth, depth, genericParams, params, spawnCheck, depth, names,
+		depth, genericParams, params, depth, strings.Join(ensures, ","), depth, values, depth, strings.TrimSuffix(grows, "\n"), depth, values, strings.TrimSuffix(appends, "\n")))
+}
+
+// func Reserve1[T1 any, ID Int](storage *Storage[ID], n int) {
+// 	storage.wlock()
+// 	defer storage.unlock()
+// 	components := [1]int{componentEnsure[T1](storage)}
+// 	var hashes [1]int
+// 	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+// 	compound.growRows(n)
+// 	compoundGrow[T1](compound, components[0], n)
+// }
+
+func buildReserveFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams []string
+	var ensures []string
+	var grows []string
+	for i := 1; i <= depth; i++ {
+		genericParams = append(genericParams, fmt.Sprintf("T%d", i))
+		ensures = append(ensures, fmt.Sprintf("componentEnsure[T%d](storage)", i))
+		grows = append(grows, fmt.Sprintf("compoundGrow[T%d](compound, components[%d], n)", i, i-1))
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// Reserve%d makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve%d[%s any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [%d]int{%s}
+	var hashes [%d]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	%s
+}
+`, depth, depth, strings.Join(genericParams, ", "), depth, strings.Join(ensures, ","), depth, strings.Join(grows, "\n")))
+}
+
+// func Get2[T1, T2 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, bool) {
+// 	storage.rlock()
+// 	defer storage.runlock()
+// 	entity, ok := storage.Entitys[id]
+// 	if !ok {
+// 		return nil, nil, false
+// 	}
+// 	compound := storage.Compounds[entity.Compound]
+// 	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+// 	if !ok {
+// 		return nil, nil, false
+// 	}
+// 	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+// 	if !ok {
+// 		return nil, nil, false
+// 	}
+// 	return v1, v2, true
+// }
+
+func buildGetFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams []string
+	var returnTypes string
+	var nils string
+	var values string
+	var lookups string
+	for i := 1; i <= depth; i++ {
+		genericParams = append(genericParams, fmt.Sprintf("T%d", i))
+		returnTypes += fmt.Sprintf("*T%d,", i)
+		nils += "nil,"
+		values += fmt.Sprintf("v%d,", i)
+	}
+	for i := 1; i <= depth; i++ {
+		lookups += fmt.Sprintf(`v%d, ok := compoundGet[T%d](storage, compound, entity.Row)
+	if !ok {
+		return %s false
+	}
+	`, i, i, nils)
+	}
+	buffer.WriteString(fmt.Sprintf(`
+func Get%d[%s any, ID Int](storage *Storage[ID], id ID) (%s bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return %s false
+	}
+	compound := storage.Compounds[entity.Compound]
+	%s
+	return %s true
+}
+`, depth, strings.Join(genericParams, ","), returnTypes, nils, lookups, values))
 }
 
 func buildQueryType(buffer *bytes.Buffer, depth int) {
@@ -109,8 +391,27 @@ func buildQueryType(buffer *bytes.Buffer, depth int) {
 		genericParams += fmt.Sprintf(",T%d any", i)
 		genericReturn += fmt.Sprintf(",T%d", i)
 	}
-	buffer.WriteString(fmt.Sprintf("type Q%d[ID Int%s]struct{\nstorage *Storage[ID]\nComponents [%d]int\nErrors []error\n}\n", depth, genericParams, depth))
-	buffer.WriteString(fmt.Sprintf("type Q%dOption struct{\nOptional [%d]bool\nStop *bool\nHash *ComponentHash\n}\n", depth, depth))
+	buffer.WriteString(fmt.Sprintf("type Q%d[ID Int%s]struct{\nstorage *Storage[ID]\ncache queryCache\nComponents [%d]int\nErrors []error\n}\n", depth, genericParams, depth))
+	var rowParams string
+	var rowFields string
+	for i := 1; i <= depth; i++ {
+		rowParams += fmt.Sprintf(",T%d any", i)
+		rowFields += fmt.Sprintf("V%d *T%d\n", i, i)
+	}
+	buffer.WriteString(fmt.Sprintf("// Q%dRow holds the components of one entity yielded by Q%d.All.\ntype Q%dRow[%s]struct{\n%s}\n", depth, depth, depth, rowParams[1:], rowFields))
+	buffer.WriteString(fmt.Sprintf(`type Q%dOption struct{
+	Optional [%d]bool
+	Stop *bool
+	Hash *ComponentHash
+	With []ComponentType // Components a compound must have
+	Without []ComponentType // Components a compound must not have
+	AnyOf []ComponentType // A compound must have at least one of these
+	Added []ComponentType // Components that must have been added after Since
+	Changed []ComponentType // Components that must have been written after Since
+	Since uint64 // Tick returned by Storage.Tick on the previous run
+	Mark [%d]bool // Query components to stamp as changed for every row visited
+}
+`, depth, depth, depth))
 }
 
 func buildQueryFunc(buffer *bytes.Buffer, depth int) {
@@ -121,15 +422,15 @@ func buildQueryFunc(buffer *bytes.Buffer, depth int) {
 		genericReturn += fmt.Sprintf(",T%d", i)
 	}
 	buffer.WriteString(fmt.Sprintf("func Query%d[%s ID Int](storage *Storage[ID]) *Q%d[ID%s]{\n", depth, genericParams, depth, genericReturn))
-	buffer.WriteString("storage.lock.RLock()\ndefer storage.lock.RUnlock()\n")
+	buffer.WriteString("storage.rlock()\ndefer storage.runlock()\n")
 	buffer.WriteString(fmt.Sprintf("q := &Q%d[ID%s]{storage: storage}\n", depth, genericReturn))
 	for i := 1; i <= depth; i++ {
 		buffer.WriteString(fmt.Sprintf(`{
-	name := typeName[T%d]()
-	id, ok := storage.getComponent(name)
+	t := typeOf[T%d]()
+	id, ok := storage.getComponent(t)
 	q.Components[%d] = id
 	if !ok {
-		q.Errors = append(q.Errors, fmt.Errorf("component %d \"%%s\" does not exist", name))
+		q.Errors = append(q.Errors, fmt.Errorf("component %d \"%%s\" does not exist", t))
 	}
 }
 `, i, i-1, i))
@@ -138,6 +439,14 @@ func buildQueryFunc(buffer *bytes.Buffer, depth int) {
 }
 
 // func (q *Q1[ID, T1]) Each(fn func(ID, *T1), queryOptions ...Q1Option) {
+// 	q.each(fn, false, queryOptions)
+// }
+//
+// func (q *Q1[ID, T1]) EachHierarchy(fn func(ID, *T1), queryOptions ...Q1Option) {
+// 	q.each(fn, true, queryOptions)
+// }
+//
+// func (q *Q1[ID, T1]) each(fn func(ID, *T1), hierarchy bool, queryOptions []Q1Option) {
 // 	// Skip if there is an error
 // 	if q.Errors != nil {
 // 		return
@@ -149,127 +458,64 @@ func buildQueryFunc(buffer *bytes.Buffer, depth int) {
 // 	if options.Stop == nil {
 // 		options.Stop = new(bool)
 // 	}
-// 	// Filter and run compounds
-// 	var compoundCleanup []int
-// 	q.storage.lock.RLock()
-// LOOP:
-// 	for id, compound := range q.storage.Compounds {
-// 		var v1s []T1
-
-// 		for _, component := range compound.Components {
-// 			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-// 				continue LOOP
-// 			}
-// 			if component.ID == q.Components[0] {
-// 				v1s = component.Data.(*slice[T1]).Data
-// 				continue
-// 			}
-
-// 		}
-// 		if v1s == nil && !options.Optional[0] {
+// 	// Purge removed rows, then run the callbacks under the read lock only
+// 	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+// 	defer q.storage.runlock()
+// 	if hierarchy {
+// 		matches = q.storage.hierarchyOrder(matches)
+// 	}
+// 	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+// 	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+// 	mark := slices.Contains(options.Mark[:], true)
+// 	tick := q.storage.tick.Load()
+// 	for _, match := range matches {
+// 		compound := q.storage.Compounds[match.compound]
+// 		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
 // 			continue
 // 		}
-
-// 		if compound.EntitysRemoved != nil {
-// 			if !compound.cleanupTime.Swap(true) {
-// 				compoundCleanup = append(compoundCleanup, id)
-// 				continue LOOP
-// 			}
-// 			loopEach:
-// 			for idx, id := range compound.Entitys {
-// 				for _, r := range compound.EntitysRemoved {
-// 					if r == id {
-// 						continue loopEach
-// 					}
-// 				}
-// 				fn(id, getOptional(v1s, idx))
-// 				if *options.Stop {
-// 					break LOOP
-// 				}
-// 			}
+// 		if match.columns[0] < 0 && !options.Optional[0] {
 // 			continue
 // 		}
-// 		// Loop
+// 		v1s := columnData[T1](compound, match.columns[0])
+// 		removed := compound.Removed
 // 		for idx, id := range compound.Entitys {
-// 			fn(id, getOptional(v1s, idx))
-// 			if *options.Stop {
-// 				// q.storage.lock.RUnlock()
-// 				break LOOP
-// 			}
-// 		}
-// 	}
-// 	q.storage.lock.RUnlock()
-// 	if compoundCleanup == nil {
-// 		return
-// 	}
-// 	// Run cleanups
-// 	q.storage.lock.Lock()
-// 	for _, id := range compoundCleanup {
-// 		compound := q.storage.Compounds[id]
-// 		// Abort if we're not going to run the data
-// 		if *options.Stop {
-// 			continue
-// 		}
-// 		// Select components
-// 		var v1s []T1
-
-// 		for _, component := range compound.Components {
-// 			if component.ID == q.Components[0] {
-// 				v1s = component.Data.(*slice[T1]).Data
+// 			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
 // 				continue
 // 			}
-
-// 		}
-// 		// Run data
-// 		idxRemove := make([]int,0, len(compound.EntitysRemoved))
-// 		loopRemove:
-// 		for idx, id := range compound.Entitys {
-// 			for rIdx, r := range compound.EntitysRemoved {
-// 				if r == id {
-// 					idxRemove = append(idxRemove, idx)
-// 					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-// 					continue loopRemove
-// 				}
-// 			}
 // 			fn(id, getOptional(v1s, idx))
-// 			if *options.Stop {
-// 				continue
+// 			if mark {
+// 				markRow(compound, match.columns, options.Mark[:], idx, tick)
 // 			}
-// 		}
-// 		// Cleanup
-// 		for i := len(idxRemove) - 1; i >= 0; i-- {
-// 			idx := idxRemove[i]
-// 			compound.Entitys = sliceRemove(compound.Entitys, idx)
-// 			for _, component := range compound.Components {
-// 				component.Data.remove(idx)
+// 			if *options.Stop {
+// 				return
 // 			}
 // 		}
-// 		compound.EntitysRemoved = nil
 // 	}
-// 	for _, id := range compoundCleanup {
-// 		compound := q.storage.Compounds[id]
-// 		compound.cleanupTime.Store(false)
-// 	}
-// 	q.storage.lock.Unlock()
 // }
 
 func buildQueryEachFunc(buffer *bytes.Buffer, depth int) {
 	var genericParams string
 	var genericReturn string
-	var sliceConstructors string
 	var sliceSelectors string
 	var sliceOptionalChecks string
 	var optionals string
 	for i := 1; i <= depth; i++ {
 		genericParams += fmt.Sprintf(",*T%d", i)
 		genericReturn += fmt.Sprintf(",T%d", i)
-		sliceConstructors += fmt.Sprintf("var v%ds []T%d\n", i, i)
-		sliceSelectors += fmt.Sprintf("if component.ID == q.Components[%d] {\nv%ds = component.Data.(*slice[T%d]).Data\ncontinue\n}\n", i-1, i, i)
-		sliceOptionalChecks += fmt.Sprintf("if v%ds == nil && !options.Optional[%d] {\ncontinue\n}\n", i, i-1)
+		sliceSelectors += fmt.Sprintf("v%ds := columnData[T%d](compound, match.columns[%d])\n", i, i, i-1)
+		sliceOptionalChecks += fmt.Sprintf("if match.columns[%d] < 0 && !options.Optional[%d] {\ncontinue\n}\n", i-1, i-1)
 		optionals += fmt.Sprintf(", getOptional(v%ds, idx)", i)
 	}
-	// optionals
 	buffer.WriteString(fmt.Sprintf(`func (q *Q%d[ID%s]) Each(fn func(ID%s), queryOptions ...Q%dOption) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q%d[ID%s]) EachHierarchy(fn func(ID%s), queryOptions ...Q%dOption) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q%d[ID%s]) each(fn func(ID%s), hierarchy bool, queryOptions []Q%dOption) {
 	// Skip if there is an error
 	if q.Errors != nil {
 		return
@@ -281,95 +527,319 @@ func buildQueryEachFunc(buffer *bytes.Buffer, depth int) {
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		%s
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			%s
-		}
-		%s
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-			loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id%s)
-				if *options.Stop {
-					break LOOP
-				}
-			}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
 			continue
 		}
-		// Loop
+		%s
+		%s
+		removed := compound.Removed
 		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
 			fn(id%s)
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
 			if *options.Stop {
-				break LOOP
+				return
 			}
 		}
 	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
+}
+`, depth, genericReturn, genericParams, depth,
+		depth, genericReturn, genericParams, depth,
+		depth, genericReturn, genericParams, depth, depth, sliceOptionalChecks, sliceSelectors, optionals))
+}
+
+// func (q *Q1[ID, T1]) EachCmd(fn func(*Commands[ID], ID, *T1), queryOptions ...Q1Option) {
+// 	cmd := q.storage.Commands()
+// 	q.Each(func(id ID, v1 *T1) { fn(cmd, id, v1) }, queryOptions...)
+// 	cmd.Apply()
+// }
+
+func buildQueryEachCmdFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams string
+	var genericReturn string
+	var params string
+	var values string
+	for i := 1; i <= depth; i++ {
+		genericParams += fmt.Sprintf(",*T%d", i)
+		genericReturn += fmt.Sprintf(",T%d", i)
+		params += fmt.Sprintf(", v%d *T%d", i, i)
+		values += fmt.Sprintf(", v%d", i)
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q%d[ID%s]) EachCmd(fn func(*Commands[ID], ID%s), queryOptions ...Q%dOption) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID%s) { fn(cmd, id%s) }, queryOptions...)
+	cmd.Apply()
+}
+`, depth, genericReturn, genericParams, depth, params, values))
+}
+
+// func (q *Q1[ID, T1]) All(queryOptions ...Q1Option) iter.Seq2[ID, Q1Row[T1]] {
+// 	return func(yield func(ID, Q1Row[T1]) bool) {
+// 		var options Q1Option
+// 		if len(queryOptions) == 1 {
+// 			options = queryOptions[0]
+// 		}
+// 		options.Stop = new(bool)
+// 		q.Each(func(id ID, v1 *T1) {
+// 			if !yield(id, Q1Row[T1]{v1}) {
+// 				*options.Stop = true
+// 			}
+// 		}, options)
+// 	}
+// }
+
+func buildQueryAllFunc(buffer *bytes.Buffer, depth int) {
+	var genericReturn string
+	var params string
+	var values []string
+	for i := 1; i <= depth; i++ {
+		genericReturn += fmt.Sprintf(",T%d", i)
+		params += fmt.Sprintf(", v%d *T%d", i, i)
+		values = append(values, fmt.Sprintf("v%d", i))
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q%d[ID%s]) All(queryOptions ...Q%dOption) iter.Seq2[ID, Q%dRow[%s]] {
+	return func(yield func(ID, Q%dRow[%s]) bool) {
+		var options Q%dOption
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID%s) {
+			if !yield(id, Q%dRow[%s]{%s}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+`, depth, genericReturn, depth, depth, genericReturn[1:], depth, genericReturn[1:], depth, params, depth, genericReturn[1:], strings.Join(values, ",")))
+}
+
+// func (q *Q1[ID, T1]) ParEach(pool *Pool, fn func(ID, *T1), queryOptions ...Q1Option) {
+// 	if q.Errors != nil {
+// 		return
+// 	}
+// 	var options Q1Option
+// 	if len(queryOptions) == 1 {
+// 		options = queryOptions[0]
+// 	}
+// 	pool = poolOrDefault(pool)
+// 	q.storage.rlock()
+// 	defer q.storage.runlock()
+// 	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+// 	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+// 	mark := slices.Contains(options.Mark[:], true)
+// 	tick := q.storage.tick.Load()
+// 	var chunks []parChunk
+// 	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+// 		compound := q.storage.Compounds[match.compound]
+// 		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+// 			continue
+// 		}
+// 		if match.columns[0] < 0 && !options.Optional[0] {
+// 			continue
+// 		}
+// 		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+// 	}
+// 	pool.run(len(chunks), func(i int) {
+// 		chunk := chunks[i]
+// 		compound := q.storage.Compounds[chunk.match.compound]
+// 		v1s := columnData[T1](compound, chunk.match.columns[0])
+// 		changes := changes.fork()
+// 		changes.bind(compound.Components)
+// 		for idx := chunk.start; idx < chunk.end; idx++ {
+// 			id := compound.Entitys[idx]
+// 			if compound.rowRemoved(idx) || !changes.row(idx) {
+// 				continue
+// 			}
+// 			fn(id, getOptional(v1s, idx))
+// 			if mark {
+// 				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+// 			}
+// 		}
+// 	})
+// }
+
+func buildQueryParEachFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams string
+	var genericReturn string
+	var sliceSelectors string
+	var sliceOptionalChecks string
+	var optionals string
+	for i := 1; i <= depth; i++ {
+		genericParams += fmt.Sprintf(",*T%d", i)
+		genericReturn += fmt.Sprintf(",T%d", i)
+		sliceSelectors += fmt.Sprintf("v%ds := columnData[T%d](compound, chunk.match.columns[%d])\n", i, i, i-1)
+		sliceOptionalChecks += fmt.Sprintf("if match.columns[%d] < 0 && !options.Optional[%d] {\ncontinue\n}\n", i-1, i-1)
+		optionals += fmt.Sprintf(", getOptional(v%ds, idx)", i)
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q%d[ID%s]) ParEach(pool *Pool, fn func(ID%s), queryOptions ...Q%dOption) {
+	if q.Errors != nil {
 		return
 	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+	var options Q%dOption
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
 			continue
 		}
-		// Select components
 		%s
-		for _, component := range compound.Components {
-			%s
-		}
-		// Run data
-		idxRemove := make([]int,0, len(compound.EntitysRemoved))
-		loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+	}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		%s
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
 			}
 			fn(id%s)
-			if *options.Stop {
-				continue
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+	})
+}
+`, depth, genericReturn, genericParams, depth, depth, sliceOptionalChecks, sliceSelectors, optionals))
+}
+
+// func (q *Q1[ID, T1]) EachChunk(fn func([]ID, []T1), queryOptions ...Q1Option) {
+// 	if q.Errors != nil {
+// 		return
+// 	}
+// 	var options Q1Option
+// 	if len(queryOptions) == 1 {
+// 		options = queryOptions[0]
+// 	}
+// 	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+// 		return
+// 	}
+// 	if options.Stop == nil {
+// 		options.Stop = new(bool)
+// 	}
+// 	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+// 	defer q.storage.runlock()
+// 	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+// 	mark := slices.Contains(options.Mark[:], true)
+// 	tick := q.storage.tick.Load()
+// 	for _, match := range matches {
+// 		compound := q.storage.Compounds[match.compound]
+// 		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+// 			continue
+// 		}
+// 		if match.columns[0] < 0 && !options.Optional[0] {
+// 			continue
+// 		}
+// 		if len(compound.Entitys) == 0 {
+// 			continue
+// 		}
+// 		fn(compound.Entitys, columnData[T1](compound, match.columns[0]))
+// 		if mark {
+// 			for idx := range compound.Entitys {
+// 				markRow(compound, match.columns, options.Mark[:], idx, tick)
+// 			}
+// 		}
+// 		if *options.Stop {
+// 			return
+// 		}
+// 	}
+// }
+
+func buildQueryEachChunkFunc(buffer *bytes.Buffer, depth int) {
+	var genericParams string
+	var genericReturn string
+	var sliceOptionalChecks string
+	var columns string
+	for i := 1; i <= depth; i++ {
+		genericParams += fmt.Sprintf(",[]T%d", i)
+		genericReturn += fmt.Sprintf(",T%d", i)
+		sliceOptionalChecks += fmt.Sprintf("if match.columns[%d] < 0 && !options.Optional[%d] {\ncontinue\n}\n", i-1, i-1)
+		columns += fmt.Sprintf(", columnData[T%d](compound, match.columns[%d])", i, i-1)
+	}
+	buffer.WriteString(fmt.Sprintf(`
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q%d[ID%s]) EachChunk(fn func([]ID%s), queryOptions ...Q%dOption) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q%dOption
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
+		}
+		%s
+		if len(compound.Entitys) == 0 {
+			continue
+		}
+		fn(compound.Entitys%s)
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
+		if *options.Stop {
+			return
+		}
 	}
-	q.storage.lock.Unlock()
 }
-`, depth, genericReturn, genericParams, depth, depth, sliceConstructors, sliceSelectors, sliceOptionalChecks, optionals, optionals,
-		sliceConstructors, sliceSelectors, optionals))
+`, depth, genericReturn, genericParams, depth, depth, sliceOptionalChecks, columns))
 }
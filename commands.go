@@ -0,0 +1,61 @@
+package ecs
+
+import "sync"
+
+// Commands records changes to a storage so they can be made while it is being iterated.
+// Recording is safe from any goroutine; Apply runs every recorded change in order under one write lock.
+type Commands[ID Int] struct {
+	lock     sync.Mutex
+	storage  *Storage[ID]
+	commands []func(*Storage[ID])
+}
+
+// Commands creates an empty command buffer for the storage.
+func (storage *Storage[ID]) Commands() *Commands[ID] {
+	return &Commands[ID]{storage: storage}
+}
+
+func (cmd *Commands[ID]) record(fn func(*Storage[ID])) {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+	cmd.commands = append(cmd.commands, fn)
+}
+
+// Spawn allocates a handle right away and creates the entity when the commands are applied.
+func (cmd *Commands[ID]) Spawn() ID {
+	id := cmd.storage.allocator.spawn()
+	cmd.record(func(storage *Storage[ID]) { storage.spawnEmpty(id) })
+	return id
+}
+
+// Remove records a Remove.
+func (cmd *Commands[ID]) Remove(id ID) {
+	cmd.record(func(storage *Storage[ID]) { storage.remove(id) })
+}
+
+// Apply runs the recorded commands under a single write lock and empties the buffer.
+// It must not be called from inside Each.
+func (cmd *Commands[ID]) Apply() {
+	cmd.lock.Lock()
+	commands := cmd.commands
+	cmd.commands = nil
+	cmd.lock.Unlock()
+	if len(commands) == 0 {
+		return
+	}
+	cmd.storage.wlock()
+	defer cmd.storage.unlock()
+	for _, fn := range commands {
+		fn(cmd.storage)
+	}
+}
+
+// CommandAdd records an Add.
+func CommandAdd[T any, ID Int](cmd *Commands[ID], id ID, v T) {
+	cmd.record(func(storage *Storage[ID]) { add(storage, id, v) })
+}
+
+// CommandUnset records an Unset.
+func CommandUnset[T any, ID Int](cmd *Commands[ID], id ID) {
+	cmd.record(func(storage *Storage[ID]) { unset[T](storage, id) })
+}
@@ -0,0 +1,89 @@
+package ecs
+
+import "reflect"
+
+// ComponentType identifies a component type in query filters.
+type ComponentType struct {
+	t reflect.Type
+}
+
+// TypeOf returns the ComponentType of T.
+func TypeOf[T any]() ComponentType {
+	return ComponentType{t: typeOf[T]()}
+}
+
+// queryFilter is the resolved form of the With, Without and AnyOf query options.
+type queryFilter struct {
+	active  bool
+	never   bool
+	with    []int
+	without []int
+	anyOf   []int
+}
+
+func (storage *Storage[ID]) filterResolve(with, without, anyOf []ComponentType) queryFilter {
+	var filter queryFilter
+	if len(with) == 0 && len(without) == 0 && len(anyOf) == 0 {
+		return filter
+	}
+	filter.active = true
+	for _, t := range with {
+		id, ok := storage.getComponent(t.t)
+		if !ok {
+			filter.never = true // Nothing can have a component that was never stored
+			return filter
+		}
+		filter.with = append(filter.with, id)
+	}
+	for _, t := range without {
+		if id, ok := storage.getComponent(t.t); ok {
+			filter.without = append(filter.without, id)
+		}
+	}
+	for _, t := range anyOf {
+		if id, ok := storage.getComponent(t.t); ok {
+			filter.anyOf = append(filter.anyOf, id)
+		}
+	}
+	if len(anyOf) > 0 && len(filter.anyOf) == 0 {
+		filter.never = true
+	}
+	return filter
+}
+
+func (filter queryFilter) match(components []CompoundComponent) bool {
+	if !filter.active {
+		return true
+	}
+	if filter.never {
+		return false
+	}
+	for _, id := range filter.with {
+		if !componentsHas(components, id) {
+			return false
+		}
+	}
+	for _, id := range filter.without {
+		if componentsHas(components, id) {
+			return false
+		}
+	}
+	if len(filter.anyOf) == 0 {
+		return true
+	}
+	for _, id := range filter.anyOf {
+		if componentsHas(components, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func componentsHas(components []CompoundComponent, id int) bool {
+	for _, component := range components {
+		if component.ID == id {
+			return true
+		}
+	}
+	return false
+}
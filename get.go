@@ -0,0 +1,25 @@
+package ecs
+
+// Get returns the T component of an entity.
+func Get[T any, ID Int](storage *Storage[ID], id ID) (*T, bool) {
+	return Get1[T](storage, id)
+}
+
+// Has reports whether an entity has a T component.
+func Has[T any, ID Int](storage *Storage[ID], id ID) bool {
+	_, ok := Get1[T](storage, id)
+	return ok
+}
+
+func compoundGet[T any, ID Int](storage *Storage[ID], compound *Compound[ID], row int) (*T, bool) {
+	id, ok := storage.getComponent(typeOf[T]())
+	if !ok {
+		return nil, false
+	}
+	for _, component := range compound.Components {
+		if component.ID == id {
+			return &component.Data.(*slice[T]).Data[row], true
+		}
+	}
+	return nil, false
+}
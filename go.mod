@@ -2,4 +2,4 @@ module github.com/KidLinus/ecs
 
 go 1.23.0
 
-require golang.org/x/text v0.21.0 // indirect
+require golang.org/x/text v0.21.0
@@ -0,0 +1,125 @@
+package ecs
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// ChildOf is the relation of an entity to its parent in the hierarchy.
+type ChildOf struct{}
+
+var ErrHierarchyCycle = errors.New("ecs: parent is a descendant of the child")
+
+// SetParent makes parent the parent of child, replacing its previous parent.
+func (storage *Storage[ID]) SetParent(child, parent ID) error {
+	storage.wlock()
+	defer storage.unlock()
+	if child == parent || slices.Contains(storage.ancestors(parent), child) {
+		return ErrHierarchyCycle
+	}
+	add(storage, child, Pair[ChildOf, ID]{Target: parent})
+	return nil
+}
+
+// RemoveParent detaches an entity from its parent, making it a root.
+func (storage *Storage[ID]) RemoveParent(child ID) {
+	Unrelate[ChildOf](storage, child)
+}
+
+// Parent returns the parent of an entity.
+func (storage *Storage[ID]) Parent(id ID) (ID, bool) {
+	return Target[ChildOf](storage, id)
+}
+
+// Children returns the direct children of an entity.
+func (storage *Storage[ID]) Children(id ID) []ID {
+	return Related[ChildOf](storage, id)
+}
+
+// Ancestors returns the parent of an entity, its parent and so on up to the root.
+func (storage *Storage[ID]) Ancestors(id ID) []ID {
+	storage.rlock()
+	defer storage.runlock()
+	return storage.ancestors(id)
+}
+
+// RemoveRecursive removes an entity and all of its descendants.
+func (storage *Storage[ID]) RemoveRecursive(id ID) {
+	storage.wlock()
+	defer storage.unlock()
+	component, ok := storage.getComponent(typeOf[Pair[ChildOf, ID]]())
+	if !ok {
+		storage.remove(id)
+		return
+	}
+	storage.removeRecursive(component, id)
+}
+
+func (storage *Storage[ID]) removeRecursive(component int, id ID) {
+	if _, ok := storage.Entitys[id]; !ok {
+		return
+	}
+	// Collect the children first, as the ChildOf cascade may orphan them. Removing the parent
+	// before visiting them stops cycles made through Relation.
+	children := storage.related(component, id)
+	storage.remove(id)
+	for _, child := range children {
+		storage.removeRecursive(component, child)
+	}
+}
+
+func (storage *Storage[ID]) ancestors(id ID) []ID {
+	component, ok := storage.getComponent(typeOf[Pair[ChildOf, ID]]())
+	if !ok {
+		return nil
+	}
+	var ancestors []ID
+	for len(ancestors) <= len(storage.Entitys) { // Bounded in case Relation made a cycle
+		entity, ok := storage.Entitys[id]
+		if !ok {
+			break
+		}
+		compound := storage.Compounds[entity.Compound]
+		idx, ok := compoundColumn(compound, component)
+		if !ok {
+			break
+		}
+		id = ID(compound.Components[idx].Hash)
+		ancestors = append(ancestors, id)
+	}
+	return ancestors
+}
+
+// hierarchyOrder sorts matches by the depth of their compound in the hierarchy. Every entity of
+// a compound shares its parent, so this visits parents before their children.
+func (storage *Storage[ID]) hierarchyOrder(matches []queryMatch) []queryMatch {
+	component, ok := storage.getComponent(typeOf[Pair[ChildOf, ID]]())
+	if !ok {
+		return matches
+	}
+	depths := map[int]int{}
+	ordered := slices.Clone(matches)
+	slices.SortStableFunc(ordered, func(a, b queryMatch) int {
+		return cmp.Compare(storage.compoundDepth(a.compound, component, depths), storage.compoundDepth(b.compound, component, depths))
+	})
+	return ordered
+}
+
+func (storage *Storage[ID]) compoundDepth(compoundIdx, component int, depths map[int]int) int {
+	if depth, ok := depths[compoundIdx]; ok {
+		return depth
+	}
+	depths[compoundIdx] = 0 // Guards against cycles
+	compound := storage.Compounds[compoundIdx]
+	idx, ok := compoundColumn(compound, component)
+	if !ok {
+		return 0
+	}
+	depth := 1
+	if parent, ok := storage.Entitys[ID(compound.Components[idx].Hash)]; ok {
+		depth += storage.compoundDepth(parent.Compound, component, depths)
+	}
+	depths[compoundIdx] = depth
+	return depth
+}
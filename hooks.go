@@ -0,0 +1,118 @@
+package ecs
+
+// componentHooks are the lifecycle hooks registered for one component type.
+type componentHooks[ID Int] struct {
+	onAdd    []func(ID, any)
+	onSet    []func(ID, any)
+	onRemove []func(ID, any)
+}
+
+// OnAdd registers fn to run when an entity gets a T component it did not have.
+func OnAdd[T any, ID Int](storage *Storage[ID], fn func(ID, T)) {
+	storage.wlock()
+	defer storage.unlock()
+	hooks := storage.hooksEnsure(componentEnsure[T](storage))
+	hooks.onAdd = append(hooks.onAdd, func(id ID, v any) { fn(id, v.(T)) })
+}
+
+// OnSet registers fn to run when the T component of an entity is overwritten.
+func OnSet[T any, ID Int](storage *Storage[ID], fn func(ID, T)) {
+	storage.wlock()
+	defer storage.unlock()
+	hooks := storage.hooksEnsure(componentEnsure[T](storage))
+	hooks.onSet = append(hooks.onSet, func(id ID, v any) { fn(id, v.(T)) })
+}
+
+// OnRemove registers fn to run with the last value of a T component when it is detached from an entity.
+// For Remove the hook runs once the entity's row is purged from its compound: when a query visits
+// the compound, the compound reaches the compact threshold, or Compact, Shrink or Save is called.
+func OnRemove[T any, ID Int](storage *Storage[ID], fn func(ID, T)) {
+	storage.wlock()
+	defer storage.unlock()
+	hooks := storage.hooksEnsure(componentEnsure[T](storage))
+	hooks.onRemove = append(hooks.onRemove, func(id ID, v any) { fn(id, v.(T)) })
+}
+
+func (storage *Storage[ID]) hooksEnsure(component int) *componentHooks[ID] {
+	if storage.hooks == nil {
+		storage.hooks = map[int]*componentHooks[ID]{}
+	}
+	hooks, ok := storage.hooks[component]
+	if !ok {
+		hooks = &componentHooks[ID]{}
+		storage.hooks[component] = hooks
+	}
+	return hooks
+}
+
+// unlock releases the write lock and then runs the hooks queued while it was held.
+func (storage *Storage[ID]) unlock() {
+	events := storage.events
+	storage.events = nil
+	if !storage.unlocked {
+		storage.lock.Unlock()
+	}
+	for _, event := range events {
+		event()
+	}
+}
+
+func (storage *Storage[ID]) hookQueue(fns []func(ID, any), id ID, v any) {
+	for _, fn := range fns {
+		storage.events = append(storage.events, func() { fn(id, v) })
+	}
+}
+
+// hookWrite queues OnSet when the entity already had the component, OnAdd otherwise.
+func hookWrite[T any, ID Int](storage *Storage[ID], component int, id ID, v T, existed bool) {
+	if len(storage.hooks) == 0 {
+		return
+	}
+	hooks, ok := storage.hooks[component]
+	if !ok {
+		return
+	}
+	if existed {
+		storage.hookQueue(hooks.onSet, id, v)
+		return
+	}
+	storage.hookQueue(hooks.onAdd, id, v)
+}
+
+// hookReplace queues OnRemove for the components of an entity's current row that are not in
+// components, and returns a bit mask of the components the row already has.
+func (storage *Storage[ID]) hookReplace(id ID, entity Entity, components []int) uint64 {
+	var existed uint64
+	compound := storage.Compounds[entity.Compound]
+	for _, column := range compound.Components {
+		idx, ok := sliceFind(components, column.ID)
+		if ok {
+			existed |= 1 << idx
+			continue
+		}
+		storage.hookRemove(column, id, entity.Row)
+	}
+	return existed
+}
+
+func (storage *Storage[ID]) hookRemove(column CompoundComponent, id ID, row int) {
+	if len(storage.hooks) == 0 {
+		return
+	}
+	if hooks, ok := storage.hooks[column.ID]; ok && len(hooks.onRemove) > 0 {
+		storage.hookQueue(hooks.onRemove, id, column.Data.get(row))
+	}
+}
+
+// compoundPurge removes rows of removed entities, in ascending order, queueing their OnRemove hooks.
+func (storage *Storage[ID]) compoundPurge(compoundIdx int, rows []int) {
+	if len(storage.hooks) > 0 {
+		compound := storage.Compounds[compoundIdx]
+		for _, row := range rows {
+			for _, column := range compound.Components {
+				storage.hookRemove(column, compound.Entitys[row], row)
+			}
+		}
+	}
+	storage.compoundRemoveRows(compoundIdx, rows)
+}
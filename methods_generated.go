@@ -3,6812 +3,12650 @@ package ecs
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 )
 
 func Set1[ID Int, T1 any](storage *Storage[ID], id ID, v1 T1) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1)}
-	hashes := []int{componentHash(v1)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
+	storage.wlock()
+	defer storage.unlock()
+	set1(storage, id, v1)
+}
 
+func set1[ID Int, T1 any](storage *Storage[ID], id ID, v1 T1) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
+	tick := storage.tick.Load()
+	components := [1]int{componentEnsure[T1](storage)}
+	hashes := [1]int{componentHash(storage, components[0], v1)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [1]uint64{tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+
+}
 
+// CommandSet1 records a Set1 to run when the commands are applied.
+func CommandSet1[ID Int, T1 any](cmd *Commands[ID], id ID, v1 T1) {
+	cmd.record(func(storage *Storage[ID]) { set1(storage, id, v1) })
 }
 
 func Set2[ID Int, T1, T2 any](storage *Storage[ID], id ID, v1 T1, v2 T2) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2)}
-	hashes := []int{componentHash(v1), componentHash(v2)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
+	storage.wlock()
+	defer storage.unlock()
+	set2(storage, id, v1, v2)
+}
 
+func set2[ID Int, T1, T2 any](storage *Storage[ID], id ID, v1 T1, v2 T2) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
+	tick := storage.tick.Load()
+	components := [2]int{componentEnsure[T1](storage), componentEnsure[T2](storage)}
+	hashes := [2]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [2]uint64{tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+
+}
 
+// CommandSet2 records a Set2 to run when the commands are applied.
+func CommandSet2[ID Int, T1, T2 any](cmd *Commands[ID], id ID, v1 T1, v2 T2) {
+	cmd.record(func(storage *Storage[ID]) { set2(storage, id, v1, v2) })
 }
 
 func Set3[ID Int, T1, T2, T3 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
+	storage.wlock()
+	defer storage.unlock()
+	set3(storage, id, v1, v2, v3)
+}
 
+func set3[ID Int, T1, T2, T3 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
+	tick := storage.tick.Load()
+	components := [3]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage)}
+	hashes := [3]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [3]uint64{tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+
+}
 
+// CommandSet3 records a Set3 to run when the commands are applied.
+func CommandSet3[ID Int, T1, T2, T3 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3) {
+	cmd.record(func(storage *Storage[ID]) { set3(storage, id, v1, v2, v3) })
 }
 
 func Set4[ID Int, T1, T2, T3, T4 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
+	storage.wlock()
+	defer storage.unlock()
+	set4(storage, id, v1, v2, v3, v4)
+}
 
+func set4[ID Int, T1, T2, T3, T4 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
+	tick := storage.tick.Load()
+	components := [4]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage)}
+	hashes := [4]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [4]uint64{tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+
+}
 
+// CommandSet4 records a Set4 to run when the commands are applied.
+func CommandSet4[ID Int, T1, T2, T3, T4 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4) {
+	cmd.record(func(storage *Storage[ID]) { set4(storage, id, v1, v2, v3, v4) })
 }
 
 func Set5[ID Int, T1, T2, T3, T4, T5 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
+	storage.wlock()
+	defer storage.unlock()
+	set5(storage, id, v1, v2, v3, v4, v5)
+}
 
+func set5[ID Int, T1, T2, T3, T4, T5 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
+	tick := storage.tick.Load()
+	components := [5]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage)}
+	hashes := [5]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [5]uint64{tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+
+}
 
+// CommandSet5 records a Set5 to run when the commands are applied.
+func CommandSet5[ID Int, T1, T2, T3, T4, T5 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) {
+	cmd.record(func(storage *Storage[ID]) { set5(storage, id, v1, v2, v3, v4, v5) })
 }
 
 func Set6[ID Int, T1, T2, T3, T4, T5, T6 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
+	storage.wlock()
+	defer storage.unlock()
+	set6(storage, id, v1, v2, v3, v4, v5, v6)
+}
 
+func set6[ID Int, T1, T2, T3, T4, T5, T6 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
+	tick := storage.tick.Load()
+	components := [6]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage)}
+	hashes := [6]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [6]uint64{tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+
+}
 
+// CommandSet6 records a Set6 to run when the commands are applied.
+func CommandSet6[ID Int, T1, T2, T3, T4, T5, T6 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6) {
+	cmd.record(func(storage *Storage[ID]) { set6(storage, id, v1, v2, v3, v4, v5, v6) })
 }
 
 func Set7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
+	storage.wlock()
+	defer storage.unlock()
+	set7(storage, id, v1, v2, v3, v4, v5, v6, v7)
+}
 
+func set7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
+	tick := storage.tick.Load()
+	components := [7]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage)}
+	hashes := [7]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [7]uint64{tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+
+}
 
+// CommandSet7 records a Set7 to run when the commands are applied.
+func CommandSet7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7) {
+	cmd.record(func(storage *Storage[ID]) { set7(storage, id, v1, v2, v3, v4, v5, v6, v7) })
 }
 
 func Set8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
+	storage.wlock()
+	defer storage.unlock()
+	set8(storage, id, v1, v2, v3, v4, v5, v6, v7, v8)
+}
 
+func set8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
+	tick := storage.tick.Load()
+	components := [8]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage)}
+	hashes := [8]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [8]uint64{tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+
+}
 
+// CommandSet8 records a Set8 to run when the commands are applied.
+func CommandSet8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8) {
+	cmd.record(func(storage *Storage[ID]) { set8(storage, id, v1, v2, v3, v4, v5, v6, v7, v8) })
 }
 
 func Set9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
+	storage.wlock()
+	defer storage.unlock()
+	set9(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9)
+}
 
+func set9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
+	tick := storage.tick.Load()
+	components := [9]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage)}
+	hashes := [9]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [9]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+
+}
 
+// CommandSet9 records a Set9 to run when the commands are applied.
+func CommandSet9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9) {
+	cmd.record(func(storage *Storage[ID]) { set9(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9) })
 }
 
 func Set10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
+	storage.wlock()
+	defer storage.unlock()
+	set10(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10)
+}
 
+func set10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
+	tick := storage.tick.Load()
+	components := [10]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage)}
+	hashes := [10]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [10]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+
+}
 
+// CommandSet10 records a Set10 to run when the commands are applied.
+func CommandSet10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10) {
+	cmd.record(func(storage *Storage[ID]) { set10(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10) })
 }
 
 func Set11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
+	storage.wlock()
+	defer storage.unlock()
+	set11(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11)
+}
 
+func set11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
+	tick := storage.tick.Load()
+	components := [11]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage)}
+	hashes := [11]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [11]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+
+}
 
+// CommandSet11 records a Set11 to run when the commands are applied.
+func CommandSet11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11) {
+	cmd.record(func(storage *Storage[ID]) { set11(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11) })
 }
 
 func Set12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
+	storage.wlock()
+	defer storage.unlock()
+	set12(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12)
+}
 
+func set12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
+	tick := storage.tick.Load()
+	components := [12]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage)}
+	hashes := [12]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [12]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+
+}
 
+// CommandSet12 records a Set12 to run when the commands are applied.
+func CommandSet12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12) {
+	cmd.record(func(storage *Storage[ID]) { set12(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12) })
 }
 
 func Set13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
+	storage.wlock()
+	defer storage.unlock()
+	set13(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13)
+}
 
+func set13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
+	tick := storage.tick.Load()
+	components := [13]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage)}
+	hashes := [13]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [13]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+
+}
 
+// CommandSet13 records a Set13 to run when the commands are applied.
+func CommandSet13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13) {
+	cmd.record(func(storage *Storage[ID]) { set13(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13) })
 }
 
 func Set14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
+	storage.wlock()
+	defer storage.unlock()
+	set14(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14)
+}
 
+func set14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
+	tick := storage.tick.Load()
+	components := [14]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage)}
+	hashes := [14]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [14]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+
+}
 
+// CommandSet14 records a Set14 to run when the commands are applied.
+func CommandSet14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14) {
+	cmd.record(func(storage *Storage[ID]) {
+		set14(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14)
+	})
 }
 
 func Set15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
+	storage.wlock()
+	defer storage.unlock()
+	set15(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15)
+}
 
+func set15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
+	tick := storage.tick.Load()
+	components := [15]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage)}
+	hashes := [15]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [15]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+
+}
 
+// CommandSet15 records a Set15 to run when the commands are applied.
+func CommandSet15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15) {
+	cmd.record(func(storage *Storage[ID]) {
+		set15(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15)
+	})
 }
 
 func Set16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15), storage.componentEnsure(v16)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15), componentHash(v16)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
-		compound.Components[15].Data = &slice[T16]{Data: []T16{v16}}
+	storage.wlock()
+	defer storage.unlock()
+	set16(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16)
+}
 
+func set16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
-	compound.Components[15].Data.(*slice[T16]).append(v16)
+	tick := storage.tick.Load()
+	components := [16]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage)}
+	hashes := [16]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15), componentHash(storage, components[15], v16)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [16]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+			compoundSet(compound, components[15], entity.Row, v16, tick)
+			hookWrite(storage, components[15], id, v16, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+	compoundAppend(compound, components[15], v16, added[15], tick)
+	hookWrite(storage, components[15], id, v16, existed&(1<<15) != 0)
+
+}
 
+// CommandSet16 records a Set16 to run when the commands are applied.
+func CommandSet16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16) {
+	cmd.record(func(storage *Storage[ID]) {
+		set16(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16)
+	})
 }
 
 func Set17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15), storage.componentEnsure(v16), storage.componentEnsure(v17)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15), componentHash(v16), componentHash(v17)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
-		compound.Components[15].Data = &slice[T16]{Data: []T16{v16}}
-		compound.Components[16].Data = &slice[T17]{Data: []T17{v17}}
+	storage.wlock()
+	defer storage.unlock()
+	set17(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17)
+}
 
+func set17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
-	compound.Components[15].Data.(*slice[T16]).append(v16)
-	compound.Components[16].Data.(*slice[T17]).append(v17)
+	tick := storage.tick.Load()
+	components := [17]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage)}
+	hashes := [17]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15), componentHash(storage, components[15], v16), componentHash(storage, components[16], v17)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [17]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+			compoundSet(compound, components[15], entity.Row, v16, tick)
+			hookWrite(storage, components[15], id, v16, true)
+			compoundSet(compound, components[16], entity.Row, v17, tick)
+			hookWrite(storage, components[16], id, v17, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+	compoundAppend(compound, components[15], v16, added[15], tick)
+	hookWrite(storage, components[15], id, v16, existed&(1<<15) != 0)
+	compoundAppend(compound, components[16], v17, added[16], tick)
+	hookWrite(storage, components[16], id, v17, existed&(1<<16) != 0)
+
+}
 
+// CommandSet17 records a Set17 to run when the commands are applied.
+func CommandSet17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17) {
+	cmd.record(func(storage *Storage[ID]) {
+		set17(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17)
+	})
 }
 
 func Set18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15), storage.componentEnsure(v16), storage.componentEnsure(v17), storage.componentEnsure(v18)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15), componentHash(v16), componentHash(v17), componentHash(v18)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
-		compound.Components[15].Data = &slice[T16]{Data: []T16{v16}}
-		compound.Components[16].Data = &slice[T17]{Data: []T17{v17}}
-		compound.Components[17].Data = &slice[T18]{Data: []T18{v18}}
+	storage.wlock()
+	defer storage.unlock()
+	set18(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18)
+}
 
+func set18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
-	compound.Components[15].Data.(*slice[T16]).append(v16)
-	compound.Components[16].Data.(*slice[T17]).append(v17)
-	compound.Components[17].Data.(*slice[T18]).append(v18)
+	tick := storage.tick.Load()
+	components := [18]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage)}
+	hashes := [18]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15), componentHash(storage, components[15], v16), componentHash(storage, components[16], v17), componentHash(storage, components[17], v18)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [18]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+			compoundSet(compound, components[15], entity.Row, v16, tick)
+			hookWrite(storage, components[15], id, v16, true)
+			compoundSet(compound, components[16], entity.Row, v17, tick)
+			hookWrite(storage, components[16], id, v17, true)
+			compoundSet(compound, components[17], entity.Row, v18, tick)
+			hookWrite(storage, components[17], id, v18, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+	compoundAppend(compound, components[15], v16, added[15], tick)
+	hookWrite(storage, components[15], id, v16, existed&(1<<15) != 0)
+	compoundAppend(compound, components[16], v17, added[16], tick)
+	hookWrite(storage, components[16], id, v17, existed&(1<<16) != 0)
+	compoundAppend(compound, components[17], v18, added[17], tick)
+	hookWrite(storage, components[17], id, v18, existed&(1<<17) != 0)
+
+}
 
+// CommandSet18 records a Set18 to run when the commands are applied.
+func CommandSet18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18) {
+	cmd.record(func(storage *Storage[ID]) {
+		set18(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18)
+	})
 }
 
 func Set19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15), storage.componentEnsure(v16), storage.componentEnsure(v17), storage.componentEnsure(v18), storage.componentEnsure(v19)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15), componentHash(v16), componentHash(v17), componentHash(v18), componentHash(v19)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
-		compound.Components[15].Data = &slice[T16]{Data: []T16{v16}}
-		compound.Components[16].Data = &slice[T17]{Data: []T17{v17}}
-		compound.Components[17].Data = &slice[T18]{Data: []T18{v18}}
-		compound.Components[18].Data = &slice[T19]{Data: []T19{v19}}
+	storage.wlock()
+	defer storage.unlock()
+	set19(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19)
+}
 
+func set19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
-	compound.Components[15].Data.(*slice[T16]).append(v16)
-	compound.Components[16].Data.(*slice[T17]).append(v17)
-	compound.Components[17].Data.(*slice[T18]).append(v18)
-	compound.Components[18].Data.(*slice[T19]).append(v19)
+	tick := storage.tick.Load()
+	components := [19]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage)}
+	hashes := [19]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15), componentHash(storage, components[15], v16), componentHash(storage, components[16], v17), componentHash(storage, components[17], v18), componentHash(storage, components[18], v19)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [19]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+			compoundSet(compound, components[15], entity.Row, v16, tick)
+			hookWrite(storage, components[15], id, v16, true)
+			compoundSet(compound, components[16], entity.Row, v17, tick)
+			hookWrite(storage, components[16], id, v17, true)
+			compoundSet(compound, components[17], entity.Row, v18, tick)
+			hookWrite(storage, components[17], id, v18, true)
+			compoundSet(compound, components[18], entity.Row, v19, tick)
+			hookWrite(storage, components[18], id, v19, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+	compoundAppend(compound, components[15], v16, added[15], tick)
+	hookWrite(storage, components[15], id, v16, existed&(1<<15) != 0)
+	compoundAppend(compound, components[16], v17, added[16], tick)
+	hookWrite(storage, components[16], id, v17, existed&(1<<16) != 0)
+	compoundAppend(compound, components[17], v18, added[17], tick)
+	hookWrite(storage, components[17], id, v18, existed&(1<<17) != 0)
+	compoundAppend(compound, components[18], v19, added[18], tick)
+	hookWrite(storage, components[18], id, v19, existed&(1<<18) != 0)
+
+}
 
+// CommandSet19 records a Set19 to run when the commands are applied.
+func CommandSet19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19) {
+	cmd.record(func(storage *Storage[ID]) {
+		set19(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19)
+	})
 }
 
 func Set20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19, v20 T20) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
-	components := []int{storage.componentEnsure(v1), storage.componentEnsure(v2), storage.componentEnsure(v3), storage.componentEnsure(v4), storage.componentEnsure(v5), storage.componentEnsure(v6), storage.componentEnsure(v7), storage.componentEnsure(v8), storage.componentEnsure(v9), storage.componentEnsure(v10), storage.componentEnsure(v11), storage.componentEnsure(v12), storage.componentEnsure(v13), storage.componentEnsure(v14), storage.componentEnsure(v15), storage.componentEnsure(v16), storage.componentEnsure(v17), storage.componentEnsure(v18), storage.componentEnsure(v19), storage.componentEnsure(v20)}
-	hashes := []int{componentHash(v1), componentHash(v2), componentHash(v3), componentHash(v4), componentHash(v5), componentHash(v6), componentHash(v7), componentHash(v8), componentHash(v9), componentHash(v10), componentHash(v11), componentHash(v12), componentHash(v13), componentHash(v14), componentHash(v15), componentHash(v16), componentHash(v17), componentHash(v18), componentHash(v19), componentHash(v20)}
-	entity := Entity{Compound: storage.compoundEnsure(components, hashes)}
-	storage.Entitys[id] = entity
-	compound := storage.Compounds[entity.Compound]
-	compound.Entitys = append(compound.Entitys, id)
-	if compound.Components[0].Data == nil {
-		compound.Components[0].Data = &slice[T1]{Data: []T1{v1}}
-		compound.Components[1].Data = &slice[T2]{Data: []T2{v2}}
-		compound.Components[2].Data = &slice[T3]{Data: []T3{v3}}
-		compound.Components[3].Data = &slice[T4]{Data: []T4{v4}}
-		compound.Components[4].Data = &slice[T5]{Data: []T5{v5}}
-		compound.Components[5].Data = &slice[T6]{Data: []T6{v6}}
-		compound.Components[6].Data = &slice[T7]{Data: []T7{v7}}
-		compound.Components[7].Data = &slice[T8]{Data: []T8{v8}}
-		compound.Components[8].Data = &slice[T9]{Data: []T9{v9}}
-		compound.Components[9].Data = &slice[T10]{Data: []T10{v10}}
-		compound.Components[10].Data = &slice[T11]{Data: []T11{v11}}
-		compound.Components[11].Data = &slice[T12]{Data: []T12{v12}}
-		compound.Components[12].Data = &slice[T13]{Data: []T13{v13}}
-		compound.Components[13].Data = &slice[T14]{Data: []T14{v14}}
-		compound.Components[14].Data = &slice[T15]{Data: []T15{v15}}
-		compound.Components[15].Data = &slice[T16]{Data: []T16{v16}}
-		compound.Components[16].Data = &slice[T17]{Data: []T17{v17}}
-		compound.Components[17].Data = &slice[T18]{Data: []T18{v18}}
-		compound.Components[18].Data = &slice[T19]{Data: []T19{v19}}
-		compound.Components[19].Data = &slice[T20]{Data: []T20{v20}}
+	storage.wlock()
+	defer storage.unlock()
+	set20(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, v20)
+}
 
+func set20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](storage *Storage[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19, v20 T20) {
+	if storage.allocator.stale(id) {
 		return
 	}
-	compound.Components[0].Data.(*slice[T1]).append(v1)
-	compound.Components[1].Data.(*slice[T2]).append(v2)
-	compound.Components[2].Data.(*slice[T3]).append(v3)
-	compound.Components[3].Data.(*slice[T4]).append(v4)
-	compound.Components[4].Data.(*slice[T5]).append(v5)
-	compound.Components[5].Data.(*slice[T6]).append(v6)
-	compound.Components[6].Data.(*slice[T7]).append(v7)
-	compound.Components[7].Data.(*slice[T8]).append(v8)
-	compound.Components[8].Data.(*slice[T9]).append(v9)
-	compound.Components[9].Data.(*slice[T10]).append(v10)
-	compound.Components[10].Data.(*slice[T11]).append(v11)
-	compound.Components[11].Data.(*slice[T12]).append(v12)
-	compound.Components[12].Data.(*slice[T13]).append(v13)
-	compound.Components[13].Data.(*slice[T14]).append(v14)
-	compound.Components[14].Data.(*slice[T15]).append(v15)
-	compound.Components[15].Data.(*slice[T16]).append(v16)
-	compound.Components[16].Data.(*slice[T17]).append(v17)
-	compound.Components[17].Data.(*slice[T18]).append(v18)
-	compound.Components[18].Data.(*slice[T19]).append(v19)
-	compound.Components[19].Data.(*slice[T20]).append(v20)
+	tick := storage.tick.Load()
+	components := [20]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage), componentEnsure[T20](storage)}
+	hashes := [20]int{componentHash(storage, components[0], v1), componentHash(storage, components[1], v2), componentHash(storage, components[2], v3), componentHash(storage, components[3], v4), componentHash(storage, components[4], v5), componentHash(storage, components[5], v6), componentHash(storage, components[6], v7), componentHash(storage, components[7], v8), componentHash(storage, components[8], v9), componentHash(storage, components[9], v10), componentHash(storage, components[10], v11), componentHash(storage, components[11], v12), componentHash(storage, components[12], v13), componentHash(storage, components[13], v14), componentHash(storage, components[14], v15), componentHash(storage, components[15], v16), componentHash(storage, components[16], v17), componentHash(storage, components[17], v18), componentHash(storage, components[18], v19), componentHash(storage, components[19], v20)}
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	var existed uint64
+	added := [20]uint64{tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick, tick}
+	if entity, ok := storage.Entitys[id]; ok {
+		if entity.Compound == compoundIdx {
+			compoundSet(compound, components[0], entity.Row, v1, tick)
+			hookWrite(storage, components[0], id, v1, true)
+			compoundSet(compound, components[1], entity.Row, v2, tick)
+			hookWrite(storage, components[1], id, v2, true)
+			compoundSet(compound, components[2], entity.Row, v3, tick)
+			hookWrite(storage, components[2], id, v3, true)
+			compoundSet(compound, components[3], entity.Row, v4, tick)
+			hookWrite(storage, components[3], id, v4, true)
+			compoundSet(compound, components[4], entity.Row, v5, tick)
+			hookWrite(storage, components[4], id, v5, true)
+			compoundSet(compound, components[5], entity.Row, v6, tick)
+			hookWrite(storage, components[5], id, v6, true)
+			compoundSet(compound, components[6], entity.Row, v7, tick)
+			hookWrite(storage, components[6], id, v7, true)
+			compoundSet(compound, components[7], entity.Row, v8, tick)
+			hookWrite(storage, components[7], id, v8, true)
+			compoundSet(compound, components[8], entity.Row, v9, tick)
+			hookWrite(storage, components[8], id, v9, true)
+			compoundSet(compound, components[9], entity.Row, v10, tick)
+			hookWrite(storage, components[9], id, v10, true)
+			compoundSet(compound, components[10], entity.Row, v11, tick)
+			hookWrite(storage, components[10], id, v11, true)
+			compoundSet(compound, components[11], entity.Row, v12, tick)
+			hookWrite(storage, components[11], id, v12, true)
+			compoundSet(compound, components[12], entity.Row, v13, tick)
+			hookWrite(storage, components[12], id, v13, true)
+			compoundSet(compound, components[13], entity.Row, v14, tick)
+			hookWrite(storage, components[13], id, v14, true)
+			compoundSet(compound, components[14], entity.Row, v15, tick)
+			hookWrite(storage, components[14], id, v15, true)
+			compoundSet(compound, components[15], entity.Row, v16, tick)
+			hookWrite(storage, components[15], id, v16, true)
+			compoundSet(compound, components[16], entity.Row, v17, tick)
+			hookWrite(storage, components[16], id, v17, true)
+			compoundSet(compound, components[17], entity.Row, v18, tick)
+			hookWrite(storage, components[17], id, v18, true)
+			compoundSet(compound, components[18], entity.Row, v19, tick)
+			hookWrite(storage, components[18], id, v19, true)
+			compoundSet(compound, components[19], entity.Row, v20, tick)
+			hookWrite(storage, components[19], id, v20, true)
+
+			return
+		}
+		existed = storage.hookReplace(id, entity, components[:])
+		storage.rowAdded(entity, components[:], added[:])
+		storage.compoundRemoveRow(entity.Compound, entity.Row)
+	}
+	storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+	compoundAppend(compound, components[0], v1, added[0], tick)
+	hookWrite(storage, components[0], id, v1, existed&(1<<0) != 0)
+	compoundAppend(compound, components[1], v2, added[1], tick)
+	hookWrite(storage, components[1], id, v2, existed&(1<<1) != 0)
+	compoundAppend(compound, components[2], v3, added[2], tick)
+	hookWrite(storage, components[2], id, v3, existed&(1<<2) != 0)
+	compoundAppend(compound, components[3], v4, added[3], tick)
+	hookWrite(storage, components[3], id, v4, existed&(1<<3) != 0)
+	compoundAppend(compound, components[4], v5, added[4], tick)
+	hookWrite(storage, components[4], id, v5, existed&(1<<4) != 0)
+	compoundAppend(compound, components[5], v6, added[5], tick)
+	hookWrite(storage, components[5], id, v6, existed&(1<<5) != 0)
+	compoundAppend(compound, components[6], v7, added[6], tick)
+	hookWrite(storage, components[6], id, v7, existed&(1<<6) != 0)
+	compoundAppend(compound, components[7], v8, added[7], tick)
+	hookWrite(storage, components[7], id, v8, existed&(1<<7) != 0)
+	compoundAppend(compound, components[8], v9, added[8], tick)
+	hookWrite(storage, components[8], id, v9, existed&(1<<8) != 0)
+	compoundAppend(compound, components[9], v10, added[9], tick)
+	hookWrite(storage, components[9], id, v10, existed&(1<<9) != 0)
+	compoundAppend(compound, components[10], v11, added[10], tick)
+	hookWrite(storage, components[10], id, v11, existed&(1<<10) != 0)
+	compoundAppend(compound, components[11], v12, added[11], tick)
+	hookWrite(storage, components[11], id, v12, existed&(1<<11) != 0)
+	compoundAppend(compound, components[12], v13, added[12], tick)
+	hookWrite(storage, components[12], id, v13, existed&(1<<12) != 0)
+	compoundAppend(compound, components[13], v14, added[13], tick)
+	hookWrite(storage, components[13], id, v14, existed&(1<<13) != 0)
+	compoundAppend(compound, components[14], v15, added[14], tick)
+	hookWrite(storage, components[14], id, v15, existed&(1<<14) != 0)
+	compoundAppend(compound, components[15], v16, added[15], tick)
+	hookWrite(storage, components[15], id, v16, existed&(1<<15) != 0)
+	compoundAppend(compound, components[16], v17, added[16], tick)
+	hookWrite(storage, components[16], id, v17, existed&(1<<16) != 0)
+	compoundAppend(compound, components[17], v18, added[17], tick)
+	hookWrite(storage, components[17], id, v18, existed&(1<<17) != 0)
+	compoundAppend(compound, components[18], v19, added[18], tick)
+	hookWrite(storage, components[18], id, v19, existed&(1<<18) != 0)
+	compoundAppend(compound, components[19], v20, added[19], tick)
+	hookWrite(storage, components[19], id, v20, existed&(1<<19) != 0)
 
 }
 
-type Q1[ID Int, T1 any] struct {
-	storage    *Storage[ID]
-	Components [1]int
-	Errors     []error
+// CommandSet20 records a Set20 to run when the commands are applied.
+func CommandSet20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](cmd *Commands[ID], id ID, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, v9 T9, v10 T10, v11 T11, v12 T12, v13 T13, v14 T14, v15 T15, v16 T16, v17 T17, v18 T18, v19 T19, v20 T20) {
+	cmd.record(func(storage *Storage[ID]) {
+		set20(storage, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, v20)
+	})
 }
-type Q1Option struct {
-	Optional [1]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch1 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch1[ID Int, T1 any](storage *Storage[ID], ids []ID, v1s []T1) {
+	if len(v1s) != len(ids) {
+		panic("ecs: SetBatch1 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch1(storage, ids, v1s)
 }
-type Q2[ID Int, T1 any, T2 any] struct {
-	storage    *Storage[ID]
-	Components [2]int
-	Errors     []error
+
+// SpawnBatch1 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch1[ID Int, T1 any](storage *Storage[ID], v1s []T1) []ID {
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch1(storage, ids, v1s)
+	return ids
 }
-type Q2Option struct {
-	Optional [2]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch1[ID Int, T1 any](storage *Storage[ID], ids []ID, v1s []T1) {
+	components := [1]int{componentEnsure[T1](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set1(storage, id, v1s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [1]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set1(storage, id, v1s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+	}
 }
-type Q3[ID Int, T1 any, T2 any, T3 any] struct {
-	storage    *Storage[ID]
-	Components [3]int
-	Errors     []error
+
+// SetBatch2 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch2[ID Int, T1, T2 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) {
+		panic("ecs: SetBatch2 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch2(storage, ids, v1s, v2s)
 }
-type Q3Option struct {
-	Optional [3]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch2 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch2[ID Int, T1, T2 any](storage *Storage[ID], v1s []T1, v2s []T2) []ID {
+	if len(v2s) != len(v1s) {
+		panic("ecs: SpawnBatch2 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch2(storage, ids, v1s, v2s)
+	return ids
 }
-type Q4[ID Int, T1 any, T2 any, T3 any, T4 any] struct {
-	storage    *Storage[ID]
-	Components [4]int
-	Errors     []error
+
+func setBatch2[ID Int, T1, T2 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2) {
+	components := [2]int{componentEnsure[T1](storage), componentEnsure[T2](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set2(storage, id, v1s[i], v2s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [2]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set2(storage, id, v1s[i], v2s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+	}
 }
-type Q4Option struct {
-	Optional [4]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch3 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch3[ID Int, T1, T2, T3 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) {
+		panic("ecs: SetBatch3 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch3(storage, ids, v1s, v2s, v3s)
 }
-type Q5[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
-	storage    *Storage[ID]
-	Components [5]int
-	Errors     []error
+
+// SpawnBatch3 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch3[ID Int, T1, T2, T3 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) {
+		panic("ecs: SpawnBatch3 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch3(storage, ids, v1s, v2s, v3s)
+	return ids
 }
-type Q5Option struct {
-	Optional [5]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch3[ID Int, T1, T2, T3 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3) {
+	components := [3]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set3(storage, id, v1s[i], v2s[i], v3s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [3]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set3(storage, id, v1s[i], v2s[i], v3s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+	}
 }
-type Q6[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any] struct {
-	storage    *Storage[ID]
-	Components [6]int
-	Errors     []error
+
+// SetBatch4 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch4[ID Int, T1, T2, T3, T4 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) {
+		panic("ecs: SetBatch4 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch4(storage, ids, v1s, v2s, v3s, v4s)
 }
-type Q6Option struct {
-	Optional [6]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch4 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch4[ID Int, T1, T2, T3, T4 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) {
+		panic("ecs: SpawnBatch4 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch4(storage, ids, v1s, v2s, v3s, v4s)
+	return ids
 }
-type Q7[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any] struct {
-	storage    *Storage[ID]
-	Components [7]int
-	Errors     []error
+
+func setBatch4[ID Int, T1, T2, T3, T4 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4) {
+	components := [4]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set4(storage, id, v1s[i], v2s[i], v3s[i], v4s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [4]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set4(storage, id, v1s[i], v2s[i], v3s[i], v4s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+	}
 }
-type Q7Option struct {
-	Optional [7]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch5 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch5[ID Int, T1, T2, T3, T4, T5 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) {
+		panic("ecs: SetBatch5 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch5(storage, ids, v1s, v2s, v3s, v4s, v5s)
 }
-type Q8[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any] struct {
-	storage    *Storage[ID]
-	Components [8]int
-	Errors     []error
+
+// SpawnBatch5 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch5[ID Int, T1, T2, T3, T4, T5 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) {
+		panic("ecs: SpawnBatch5 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch5(storage, ids, v1s, v2s, v3s, v4s, v5s)
+	return ids
 }
-type Q8Option struct {
-	Optional [8]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch5[ID Int, T1, T2, T3, T4, T5 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5) {
+	components := [5]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set5(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [5]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set5(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+	}
 }
-type Q9[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any] struct {
-	storage    *Storage[ID]
-	Components [9]int
-	Errors     []error
+
+// SetBatch6 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch6[ID Int, T1, T2, T3, T4, T5, T6 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) {
+		panic("ecs: SetBatch6 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch6(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s)
 }
-type Q9Option struct {
-	Optional [9]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch6 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch6[ID Int, T1, T2, T3, T4, T5, T6 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) {
+		panic("ecs: SpawnBatch6 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch6(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s)
+	return ids
 }
-type Q10[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any] struct {
-	storage    *Storage[ID]
-	Components [10]int
-	Errors     []error
+
+func setBatch6[ID Int, T1, T2, T3, T4, T5, T6 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6) {
+	components := [6]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set6(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [6]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set6(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+	}
 }
-type Q10Option struct {
-	Optional [10]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch7 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) {
+		panic("ecs: SetBatch7 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch7(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s)
 }
-type Q11[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any] struct {
-	storage    *Storage[ID]
-	Components [11]int
-	Errors     []error
+
+// SpawnBatch7 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) {
+		panic("ecs: SpawnBatch7 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch7(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s)
+	return ids
 }
-type Q11Option struct {
-	Optional [11]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch7[ID Int, T1, T2, T3, T4, T5, T6, T7 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7) {
+	components := [7]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set7(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [7]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set7(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+	}
 }
-type Q12[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any] struct {
-	storage    *Storage[ID]
-	Components [12]int
-	Errors     []error
+
+// SetBatch8 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) {
+		panic("ecs: SetBatch8 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch8(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s)
 }
-type Q12Option struct {
-	Optional [12]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch8 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) {
+		panic("ecs: SpawnBatch8 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch8(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s)
+	return ids
 }
-type Q13[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any] struct {
-	storage    *Storage[ID]
-	Components [13]int
-	Errors     []error
-}
-type Q13Option struct {
-	Optional [13]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch8[ID Int, T1, T2, T3, T4, T5, T6, T7, T8 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8) {
+	components := [8]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set8(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [8]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set8(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+	}
 }
-type Q14[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any] struct {
-	storage    *Storage[ID]
-	Components [14]int
-	Errors     []error
+
+// SetBatch9 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) {
+		panic("ecs: SetBatch9 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch9(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s)
 }
-type Q14Option struct {
-	Optional [14]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch9 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) {
+		panic("ecs: SpawnBatch9 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch9(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s)
+	return ids
 }
-type Q15[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any] struct {
-	storage    *Storage[ID]
-	Components [15]int
-	Errors     []error
+
+func setBatch9[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9) {
+	components := [9]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set9(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [9]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set9(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+	}
 }
-type Q15Option struct {
-	Optional [15]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch10 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) {
+		panic("ecs: SetBatch10 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch10(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s)
 }
-type Q16[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any] struct {
-	storage    *Storage[ID]
-	Components [16]int
-	Errors     []error
+
+// SpawnBatch10 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) {
+		panic("ecs: SpawnBatch10 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch10(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s)
+	return ids
 }
-type Q16Option struct {
-	Optional [16]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch10[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10) {
+	components := [10]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set10(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [10]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set10(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+	}
 }
-type Q17[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any] struct {
-	storage    *Storage[ID]
-	Components [17]int
-	Errors     []error
+
+// SetBatch11 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) {
+		panic("ecs: SetBatch11 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch11(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s)
 }
-type Q17Option struct {
-	Optional [17]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch11 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) {
+		panic("ecs: SpawnBatch11 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch11(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s)
+	return ids
 }
-type Q18[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any] struct {
-	storage    *Storage[ID]
-	Components [18]int
-	Errors     []error
+
+func setBatch11[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11) {
+	components := [11]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set11(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [11]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set11(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+	}
 }
-type Q18Option struct {
-	Optional [18]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SetBatch12 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) {
+		panic("ecs: SetBatch12 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch12(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s)
 }
-type Q19[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any] struct {
-	storage    *Storage[ID]
-	Components [19]int
-	Errors     []error
+
+// SpawnBatch12 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) {
+		panic("ecs: SpawnBatch12 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch12(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s)
+	return ids
 }
-type Q19Option struct {
-	Optional [19]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+func setBatch12[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12) {
+	components := [12]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set12(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [12]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set12(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+	}
 }
-type Q20[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, T20 any] struct {
-	storage    *Storage[ID]
-	Components [20]int
-	Errors     []error
+
+// SetBatch13 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) {
+		panic("ecs: SetBatch13 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch13(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s)
 }
-type Q20Option struct {
-	Optional [20]bool
-	Stop     *bool
-	Hash     *ComponentHash
+
+// SpawnBatch13 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) {
+		panic("ecs: SpawnBatch13 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch13(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s)
+	return ids
 }
 
-func Query1[T1 any, ID Int](storage *Storage[ID]) *Q1[ID, T1] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q1[ID, T1]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+func setBatch13[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13) {
+	components := [13]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set13(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i])
 		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [13]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set13(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
 	}
-	return q
 }
 
-func Query2[T1 any, T2 any, ID Int](storage *Storage[ID]) *Q2[ID, T1, T2] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q2[ID, T1, T2]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// SetBatch14 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) {
+		panic("ecs: SetBatch14 needs one value per id")
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch14(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s)
+}
+
+// SpawnBatch14 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) {
+		panic("ecs: SpawnBatch14 needs slices of equal length")
 	}
-	return q
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch14(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s)
+	return ids
 }
 
-func Query3[T1 any, T2 any, T3 any, ID Int](storage *Storage[ID]) *Q3[ID, T1, T2, T3] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q3[ID, T1, T2, T3]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+func setBatch14[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14) {
+	components := [14]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set14(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i])
 		}
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	tick := storage.tick.Load()
+	var hashes [14]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set14(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+}
+
+// SetBatch15 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) {
+		panic("ecs: SetBatch15 needs one value per id")
 	}
-	return q
+	storage.wlock()
+	defer storage.unlock()
+	setBatch15(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s)
 }
 
-func Query4[T1 any, T2 any, T3 any, T4 any, ID Int](storage *Storage[ID]) *Q4[ID, T1, T2, T3, T4] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q4[ID, T1, T2, T3, T4]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// SpawnBatch15 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) {
+		panic("ecs: SpawnBatch15 needs slices of equal length")
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	setBatch15(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s)
+	return ids
+}
+
+func setBatch15[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15) {
+	components := [15]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set15(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i])
 		}
+		return
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
-		}
+	tick := storage.tick.Load()
+	var hashes [15]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set15(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
 	}
-	return q
 }
 
-func Query5[T1 any, T2 any, T3 any, T4 any, T5 any, ID Int](storage *Storage[ID]) *Q5[ID, T1, T2, T3, T4, T5] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q5[ID, T1, T2, T3, T4, T5]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// SetBatch16 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) {
+		panic("ecs: SetBatch16 needs one value per id")
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch16(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s)
+}
+
+// SpawnBatch16 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) {
+		panic("ecs: SpawnBatch16 needs slices of equal length")
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+	setBatch16(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s)
+	return ids
+}
+
+func setBatch16[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16) {
+	components := [16]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set16(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i])
 		}
+		return
 	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
-		}
+	tick := storage.tick.Load()
+	var hashes [16]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	compoundGrow[T16](compound, components[15], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set16(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
+		compoundAppend(compound, components[15], v16s[i], tick, tick)
+		hookWrite(storage, components[15], id, v16s[i], false)
 	}
-	return q
 }
 
-func Query6[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, ID Int](storage *Storage[ID]) *Q6[ID, T1, T2, T3, T4, T5, T6] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q6[ID, T1, T2, T3, T4, T5, T6]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// SetBatch17 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) {
+		panic("ecs: SetBatch17 needs one value per id")
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch17(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s)
+}
+
+// SpawnBatch17 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) {
+		panic("ecs: SpawnBatch17 needs slices of equal length")
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+	setBatch17(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s)
+	return ids
+}
+
+func setBatch17[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17) {
+	components := [17]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set17(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i])
 		}
+		return
 	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
-		}
+	tick := storage.tick.Load()
+	var hashes [17]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	compoundGrow[T16](compound, components[15], len(ids))
+	compoundGrow[T17](compound, components[16], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set17(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
+		compoundAppend(compound, components[15], v16s[i], tick, tick)
+		hookWrite(storage, components[15], id, v16s[i], false)
+		compoundAppend(compound, components[16], v17s[i], tick, tick)
+		hookWrite(storage, components[16], id, v17s[i], false)
 	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
-		}
+}
+
+// SetBatch18 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) {
+		panic("ecs: SetBatch18 needs one value per id")
 	}
-	return q
+	storage.wlock()
+	defer storage.unlock()
+	setBatch18(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s)
 }
 
-func Query7[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, ID Int](storage *Storage[ID]) *Q7[ID, T1, T2, T3, T4, T5, T6, T7] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q7[ID, T1, T2, T3, T4, T5, T6, T7]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// SpawnBatch18 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) {
+		panic("ecs: SpawnBatch18 needs slices of equal length")
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	setBatch18(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s)
+	return ids
+}
+
+func setBatch18[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18) {
+	components := [18]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set18(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i])
 		}
+		return
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
-		}
+	tick := storage.tick.Load()
+	var hashes [18]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	compoundGrow[T16](compound, components[15], len(ids))
+	compoundGrow[T17](compound, components[16], len(ids))
+	compoundGrow[T18](compound, components[17], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set18(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
+		compoundAppend(compound, components[15], v16s[i], tick, tick)
+		hookWrite(storage, components[15], id, v16s[i], false)
+		compoundAppend(compound, components[16], v17s[i], tick, tick)
+		hookWrite(storage, components[16], id, v17s[i], false)
+		compoundAppend(compound, components[17], v18s[i], tick, tick)
+		hookWrite(storage, components[17], id, v18s[i], false)
 	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
+}
+
+// SetBatch19 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) || len(v19s) != len(v1s) {
+		panic("ecs: SetBatch19 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch19(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s, v19s)
+}
+
+// SpawnBatch19 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) || len(v19s) != len(v1s) {
+		panic("ecs: SpawnBatch19 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch19(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s, v19s)
+	return ids
+}
+
+func setBatch19[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19) {
+	components := [19]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set19(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i], v19s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [19]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	compoundGrow[T16](compound, components[15], len(ids))
+	compoundGrow[T17](compound, components[16], len(ids))
+	compoundGrow[T18](compound, components[17], len(ids))
+	compoundGrow[T19](compound, components[18], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set19(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i], v19s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
+		compoundAppend(compound, components[15], v16s[i], tick, tick)
+		hookWrite(storage, components[15], id, v16s[i], false)
+		compoundAppend(compound, components[16], v17s[i], tick, tick)
+		hookWrite(storage, components[16], id, v17s[i], false)
+		compoundAppend(compound, components[17], v18s[i], tick, tick)
+		hookWrite(storage, components[17], id, v18s[i], false)
+		compoundAppend(compound, components[18], v19s[i], tick, tick)
+		hookWrite(storage, components[18], id, v19s[i], false)
+	}
+}
+
+// SetBatch20 sets the components of many entities under one lock. Entities that do not exist yet are
+// appended to their compound in bulk. Every slice must be as long as ids.
+func SetBatch20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19, v20s []T20) {
+	if len(v1s) != len(ids) || len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) || len(v19s) != len(v1s) || len(v20s) != len(v1s) {
+		panic("ecs: SetBatch20 needs one value per id")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	setBatch20(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s, v19s, v20s)
+}
+
+// SpawnBatch20 allocates a handle per entity, skipping IDs taken like Spawn, and creates the entities with their components.
+func SpawnBatch20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](storage *Storage[ID], v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19, v20s []T20) []ID {
+	if len(v2s) != len(v1s) || len(v3s) != len(v1s) || len(v4s) != len(v1s) || len(v5s) != len(v1s) || len(v6s) != len(v1s) || len(v7s) != len(v1s) || len(v8s) != len(v1s) || len(v9s) != len(v1s) || len(v10s) != len(v1s) || len(v11s) != len(v1s) || len(v12s) != len(v1s) || len(v13s) != len(v1s) || len(v14s) != len(v1s) || len(v15s) != len(v1s) || len(v16s) != len(v1s) || len(v17s) != len(v1s) || len(v18s) != len(v1s) || len(v19s) != len(v1s) || len(v20s) != len(v1s) {
+		panic("ecs: SpawnBatch20 needs slices of equal length")
+	}
+	storage.wlock()
+	defer storage.unlock()
+	ids := make([]ID, len(v1s))
+	for i := range ids {
+		ids[i] = storage.spawnHandle()
+	}
+	setBatch20(storage, ids, v1s, v2s, v3s, v4s, v5s, v6s, v7s, v8s, v9s, v10s, v11s, v12s, v13s, v14s, v15s, v16s, v17s, v18s, v19s, v20s)
+	return ids
+}
+
+func setBatch20[ID Int, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any](storage *Storage[ID], ids []ID, v1s []T1, v2s []T2, v3s []T3, v4s []T4, v5s []T5, v6s []T6, v7s []T7, v8s []T8, v9s []T9, v10s []T10, v11s []T11, v12s []T12, v13s []T13, v14s []T14, v15s []T15, v16s []T16, v17s []T17, v18s []T18, v19s []T19, v20s []T20) {
+	components := [20]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage), componentEnsure[T20](storage)}
+	if storage.componentsHashable(components[:]) {
+		for i, id := range ids {
+			set20(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i], v19s[i], v20s[i])
+		}
+		return
+	}
+	tick := storage.tick.Load()
+	var hashes [20]int
+	compoundIdx := storage.compoundEnsure(components[:], hashes[:])
+	compound := storage.Compounds[compoundIdx]
+	compound.growRows(len(ids))
+	compoundGrow[T1](compound, components[0], len(ids))
+	compoundGrow[T2](compound, components[1], len(ids))
+	compoundGrow[T3](compound, components[2], len(ids))
+	compoundGrow[T4](compound, components[3], len(ids))
+	compoundGrow[T5](compound, components[4], len(ids))
+	compoundGrow[T6](compound, components[5], len(ids))
+	compoundGrow[T7](compound, components[6], len(ids))
+	compoundGrow[T8](compound, components[7], len(ids))
+	compoundGrow[T9](compound, components[8], len(ids))
+	compoundGrow[T10](compound, components[9], len(ids))
+	compoundGrow[T11](compound, components[10], len(ids))
+	compoundGrow[T12](compound, components[11], len(ids))
+	compoundGrow[T13](compound, components[12], len(ids))
+	compoundGrow[T14](compound, components[13], len(ids))
+	compoundGrow[T15](compound, components[14], len(ids))
+	compoundGrow[T16](compound, components[15], len(ids))
+	compoundGrow[T17](compound, components[16], len(ids))
+	compoundGrow[T18](compound, components[17], len(ids))
+	compoundGrow[T19](compound, components[18], len(ids))
+	compoundGrow[T20](compound, components[19], len(ids))
+	for i, id := range ids {
+		if _, ok := storage.Entitys[id]; ok || storage.allocator.stale(id) {
+			set20(storage, id, v1s[i], v2s[i], v3s[i], v4s[i], v5s[i], v6s[i], v7s[i], v8s[i], v9s[i], v10s[i], v11s[i], v12s[i], v13s[i], v14s[i], v15s[i], v16s[i], v17s[i], v18s[i], v19s[i], v20s[i])
+			continue
+		}
+		storage.Entitys[id] = Entity{Compound: compoundIdx, Row: compound.appendRow(id)}
+		compoundAppend(compound, components[0], v1s[i], tick, tick)
+		hookWrite(storage, components[0], id, v1s[i], false)
+		compoundAppend(compound, components[1], v2s[i], tick, tick)
+		hookWrite(storage, components[1], id, v2s[i], false)
+		compoundAppend(compound, components[2], v3s[i], tick, tick)
+		hookWrite(storage, components[2], id, v3s[i], false)
+		compoundAppend(compound, components[3], v4s[i], tick, tick)
+		hookWrite(storage, components[3], id, v4s[i], false)
+		compoundAppend(compound, components[4], v5s[i], tick, tick)
+		hookWrite(storage, components[4], id, v5s[i], false)
+		compoundAppend(compound, components[5], v6s[i], tick, tick)
+		hookWrite(storage, components[5], id, v6s[i], false)
+		compoundAppend(compound, components[6], v7s[i], tick, tick)
+		hookWrite(storage, components[6], id, v7s[i], false)
+		compoundAppend(compound, components[7], v8s[i], tick, tick)
+		hookWrite(storage, components[7], id, v8s[i], false)
+		compoundAppend(compound, components[8], v9s[i], tick, tick)
+		hookWrite(storage, components[8], id, v9s[i], false)
+		compoundAppend(compound, components[9], v10s[i], tick, tick)
+		hookWrite(storage, components[9], id, v10s[i], false)
+		compoundAppend(compound, components[10], v11s[i], tick, tick)
+		hookWrite(storage, components[10], id, v11s[i], false)
+		compoundAppend(compound, components[11], v12s[i], tick, tick)
+		hookWrite(storage, components[11], id, v12s[i], false)
+		compoundAppend(compound, components[12], v13s[i], tick, tick)
+		hookWrite(storage, components[12], id, v13s[i], false)
+		compoundAppend(compound, components[13], v14s[i], tick, tick)
+		hookWrite(storage, components[13], id, v14s[i], false)
+		compoundAppend(compound, components[14], v15s[i], tick, tick)
+		hookWrite(storage, components[14], id, v15s[i], false)
+		compoundAppend(compound, components[15], v16s[i], tick, tick)
+		hookWrite(storage, components[15], id, v16s[i], false)
+		compoundAppend(compound, components[16], v17s[i], tick, tick)
+		hookWrite(storage, components[16], id, v17s[i], false)
+		compoundAppend(compound, components[17], v18s[i], tick, tick)
+		hookWrite(storage, components[17], id, v18s[i], false)
+		compoundAppend(compound, components[18], v19s[i], tick, tick)
+		hookWrite(storage, components[18], id, v19s[i], false)
+		compoundAppend(compound, components[19], v20s[i], tick, tick)
+		hookWrite(storage, components[19], id, v20s[i], false)
+	}
+}
+
+// Reserve1 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve1[T1 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [1]int{componentEnsure[T1](storage)}
+	var hashes [1]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+}
+
+// Reserve2 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve2[T1, T2 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [2]int{componentEnsure[T1](storage), componentEnsure[T2](storage)}
+	var hashes [2]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+}
+
+// Reserve3 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve3[T1, T2, T3 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [3]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage)}
+	var hashes [3]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+}
+
+// Reserve4 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve4[T1, T2, T3, T4 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [4]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage)}
+	var hashes [4]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+}
+
+// Reserve5 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve5[T1, T2, T3, T4, T5 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [5]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage)}
+	var hashes [5]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+}
+
+// Reserve6 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve6[T1, T2, T3, T4, T5, T6 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [6]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage)}
+	var hashes [6]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+}
+
+// Reserve7 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve7[T1, T2, T3, T4, T5, T6, T7 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [7]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage)}
+	var hashes [7]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+}
+
+// Reserve8 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve8[T1, T2, T3, T4, T5, T6, T7, T8 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [8]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage)}
+	var hashes [8]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+}
+
+// Reserve9 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve9[T1, T2, T3, T4, T5, T6, T7, T8, T9 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [9]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage)}
+	var hashes [9]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+}
+
+// Reserve10 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve10[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [10]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage)}
+	var hashes [10]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+}
+
+// Reserve11 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve11[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [11]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage)}
+	var hashes [11]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+}
+
+// Reserve12 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve12[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [12]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage)}
+	var hashes [12]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+}
+
+// Reserve13 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve13[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [13]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage)}
+	var hashes [13]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+}
+
+// Reserve14 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve14[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [14]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage)}
+	var hashes [14]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+}
+
+// Reserve15 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve15[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [15]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage)}
+	var hashes [15]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+}
+
+// Reserve16 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve16[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [16]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage)}
+	var hashes [16]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+	compoundGrow[T16](compound, components[15], n)
+}
+
+// Reserve17 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve17[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [17]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage)}
+	var hashes [17]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+	compoundGrow[T16](compound, components[15], n)
+	compoundGrow[T17](compound, components[16], n)
+}
+
+// Reserve18 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve18[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [18]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage)}
+	var hashes [18]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+	compoundGrow[T16](compound, components[15], n)
+	compoundGrow[T17](compound, components[16], n)
+	compoundGrow[T18](compound, components[17], n)
+}
+
+// Reserve19 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve19[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [19]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage)}
+	var hashes [19]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+	compoundGrow[T16](compound, components[15], n)
+	compoundGrow[T17](compound, components[16], n)
+	compoundGrow[T18](compound, components[17], n)
+	compoundGrow[T19](compound, components[18], n)
+}
+
+// Reserve20 makes room for n more entities with exactly these components, so Sets of them do not
+// grow the compound. Hashable components reserve the compound of a zero Hash.
+func Reserve20[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any, ID Int](storage *Storage[ID], n int) {
+	storage.wlock()
+	defer storage.unlock()
+	components := [20]int{componentEnsure[T1](storage), componentEnsure[T2](storage), componentEnsure[T3](storage), componentEnsure[T4](storage), componentEnsure[T5](storage), componentEnsure[T6](storage), componentEnsure[T7](storage), componentEnsure[T8](storage), componentEnsure[T9](storage), componentEnsure[T10](storage), componentEnsure[T11](storage), componentEnsure[T12](storage), componentEnsure[T13](storage), componentEnsure[T14](storage), componentEnsure[T15](storage), componentEnsure[T16](storage), componentEnsure[T17](storage), componentEnsure[T18](storage), componentEnsure[T19](storage), componentEnsure[T20](storage)}
+	var hashes [20]int
+	compound := storage.Compounds[storage.compoundEnsure(components[:], hashes[:])]
+	compound.growRows(n)
+	compoundGrow[T1](compound, components[0], n)
+	compoundGrow[T2](compound, components[1], n)
+	compoundGrow[T3](compound, components[2], n)
+	compoundGrow[T4](compound, components[3], n)
+	compoundGrow[T5](compound, components[4], n)
+	compoundGrow[T6](compound, components[5], n)
+	compoundGrow[T7](compound, components[6], n)
+	compoundGrow[T8](compound, components[7], n)
+	compoundGrow[T9](compound, components[8], n)
+	compoundGrow[T10](compound, components[9], n)
+	compoundGrow[T11](compound, components[10], n)
+	compoundGrow[T12](compound, components[11], n)
+	compoundGrow[T13](compound, components[12], n)
+	compoundGrow[T14](compound, components[13], n)
+	compoundGrow[T15](compound, components[14], n)
+	compoundGrow[T16](compound, components[15], n)
+	compoundGrow[T17](compound, components[16], n)
+	compoundGrow[T18](compound, components[17], n)
+	compoundGrow[T19](compound, components[18], n)
+	compoundGrow[T20](compound, components[19], n)
+}
+
+func Get1[T1 any, ID Int](storage *Storage[ID], id ID) (*T1, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, false
+	}
+
+	return v1, true
+}
+
+func Get2[T1, T2 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return v1, v2, true
+}
+
+func Get3[T1, T2, T3 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	return v1, v2, v3, true
+}
+
+func Get4[T1, T2, T3, T4 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, true
+}
+
+func Get5[T1, T2, T3, T4, T5 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, true
+}
+
+func Get6[T1, T2, T3, T4, T5, T6 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, true
+}
+
+func Get7[T1, T2, T3, T4, T5, T6, T7 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, true
+}
+
+func Get8[T1, T2, T3, T4, T5, T6, T7, T8 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, true
+}
+
+func Get9[T1, T2, T3, T4, T5, T6, T7, T8, T9 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, true
+}
+
+func Get10[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, true
+}
+
+func Get11[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, true
+}
+
+func Get12[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, true
+}
+
+func Get13[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, true
+}
+
+func Get14[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, true
+}
+
+func Get15[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, true
+}
+
+func Get16[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v16, ok := compoundGet[T16](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, true
+}
+
+func Get17[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v16, ok := compoundGet[T16](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v17, ok := compoundGet[T17](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, true
+}
+
+func Get18[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v16, ok := compoundGet[T16](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v17, ok := compoundGet[T17](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v18, ok := compoundGet[T18](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, true
+}
+
+func Get19[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v16, ok := compoundGet[T16](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v17, ok := compoundGet[T17](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v18, ok := compoundGet[T18](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v19, ok := compoundGet[T19](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, true
+}
+
+func Get20[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20 any, ID Int](storage *Storage[ID], id ID) (*T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	entity, ok := storage.Entitys[id]
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	compound := storage.Compounds[entity.Compound]
+	v1, ok := compoundGet[T1](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v2, ok := compoundGet[T2](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v3, ok := compoundGet[T3](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v4, ok := compoundGet[T4](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v5, ok := compoundGet[T5](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v6, ok := compoundGet[T6](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v7, ok := compoundGet[T7](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v8, ok := compoundGet[T8](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v9, ok := compoundGet[T9](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v10, ok := compoundGet[T10](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v11, ok := compoundGet[T11](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v12, ok := compoundGet[T12](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v13, ok := compoundGet[T13](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v14, ok := compoundGet[T14](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v15, ok := compoundGet[T15](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v16, ok := compoundGet[T16](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v17, ok := compoundGet[T17](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v18, ok := compoundGet[T18](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v19, ok := compoundGet[T19](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+	v20, ok := compoundGet[T20](storage, compound, entity.Row)
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false
+	}
+
+	return v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, v20, true
+}
+
+type Q1[ID Int, T1 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [1]int
+	Errors     []error
+}
+
+// Q1Row holds the components of one entity yielded by Q1.All.
+type Q1Row[T1 any] struct {
+	V1 *T1
+}
+type Q1Option struct {
+	Optional [1]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [1]bool         // Query components to stamp as changed for every row visited
+}
+type Q2[ID Int, T1 any, T2 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [2]int
+	Errors     []error
+}
+
+// Q2Row holds the components of one entity yielded by Q2.All.
+type Q2Row[T1 any, T2 any] struct {
+	V1 *T1
+	V2 *T2
+}
+type Q2Option struct {
+	Optional [2]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [2]bool         // Query components to stamp as changed for every row visited
+}
+type Q3[ID Int, T1 any, T2 any, T3 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [3]int
+	Errors     []error
+}
+
+// Q3Row holds the components of one entity yielded by Q3.All.
+type Q3Row[T1 any, T2 any, T3 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+}
+type Q3Option struct {
+	Optional [3]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [3]bool         // Query components to stamp as changed for every row visited
+}
+type Q4[ID Int, T1 any, T2 any, T3 any, T4 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [4]int
+	Errors     []error
+}
+
+// Q4Row holds the components of one entity yielded by Q4.All.
+type Q4Row[T1 any, T2 any, T3 any, T4 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+}
+type Q4Option struct {
+	Optional [4]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [4]bool         // Query components to stamp as changed for every row visited
+}
+type Q5[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [5]int
+	Errors     []error
+}
+
+// Q5Row holds the components of one entity yielded by Q5.All.
+type Q5Row[T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+	V5 *T5
+}
+type Q5Option struct {
+	Optional [5]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [5]bool         // Query components to stamp as changed for every row visited
+}
+type Q6[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [6]int
+	Errors     []error
+}
+
+// Q6Row holds the components of one entity yielded by Q6.All.
+type Q6Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+	V5 *T5
+	V6 *T6
+}
+type Q6Option struct {
+	Optional [6]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [6]bool         // Query components to stamp as changed for every row visited
+}
+type Q7[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [7]int
+	Errors     []error
+}
+
+// Q7Row holds the components of one entity yielded by Q7.All.
+type Q7Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+	V5 *T5
+	V6 *T6
+	V7 *T7
+}
+type Q7Option struct {
+	Optional [7]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [7]bool         // Query components to stamp as changed for every row visited
+}
+type Q8[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [8]int
+	Errors     []error
+}
+
+// Q8Row holds the components of one entity yielded by Q8.All.
+type Q8Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+	V5 *T5
+	V6 *T6
+	V7 *T7
+	V8 *T8
+}
+type Q8Option struct {
+	Optional [8]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [8]bool         // Query components to stamp as changed for every row visited
+}
+type Q9[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [9]int
+	Errors     []error
+}
+
+// Q9Row holds the components of one entity yielded by Q9.All.
+type Q9Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any] struct {
+	V1 *T1
+	V2 *T2
+	V3 *T3
+	V4 *T4
+	V5 *T5
+	V6 *T6
+	V7 *T7
+	V8 *T8
+	V9 *T9
+}
+type Q9Option struct {
+	Optional [9]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [9]bool         // Query components to stamp as changed for every row visited
+}
+type Q10[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [10]int
+	Errors     []error
+}
+
+// Q10Row holds the components of one entity yielded by Q10.All.
+type Q10Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+}
+type Q10Option struct {
+	Optional [10]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [10]bool        // Query components to stamp as changed for every row visited
+}
+type Q11[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [11]int
+	Errors     []error
+}
+
+// Q11Row holds the components of one entity yielded by Q11.All.
+type Q11Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+}
+type Q11Option struct {
+	Optional [11]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [11]bool        // Query components to stamp as changed for every row visited
+}
+type Q12[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [12]int
+	Errors     []error
+}
+
+// Q12Row holds the components of one entity yielded by Q12.All.
+type Q12Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+}
+type Q12Option struct {
+	Optional [12]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [12]bool        // Query components to stamp as changed for every row visited
+}
+type Q13[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [13]int
+	Errors     []error
+}
+
+// Q13Row holds the components of one entity yielded by Q13.All.
+type Q13Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+}
+type Q13Option struct {
+	Optional [13]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [13]bool        // Query components to stamp as changed for every row visited
+}
+type Q14[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [14]int
+	Errors     []error
+}
+
+// Q14Row holds the components of one entity yielded by Q14.All.
+type Q14Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+}
+type Q14Option struct {
+	Optional [14]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [14]bool        // Query components to stamp as changed for every row visited
+}
+type Q15[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [15]int
+	Errors     []error
+}
+
+// Q15Row holds the components of one entity yielded by Q15.All.
+type Q15Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+}
+type Q15Option struct {
+	Optional [15]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [15]bool        // Query components to stamp as changed for every row visited
+}
+type Q16[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [16]int
+	Errors     []error
+}
+
+// Q16Row holds the components of one entity yielded by Q16.All.
+type Q16Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+	V16 *T16
+}
+type Q16Option struct {
+	Optional [16]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [16]bool        // Query components to stamp as changed for every row visited
+}
+type Q17[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [17]int
+	Errors     []error
+}
+
+// Q17Row holds the components of one entity yielded by Q17.All.
+type Q17Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+	V16 *T16
+	V17 *T17
+}
+type Q17Option struct {
+	Optional [17]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [17]bool        // Query components to stamp as changed for every row visited
+}
+type Q18[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [18]int
+	Errors     []error
+}
+
+// Q18Row holds the components of one entity yielded by Q18.All.
+type Q18Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+	V16 *T16
+	V17 *T17
+	V18 *T18
+}
+type Q18Option struct {
+	Optional [18]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [18]bool        // Query components to stamp as changed for every row visited
+}
+type Q19[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [19]int
+	Errors     []error
+}
+
+// Q19Row holds the components of one entity yielded by Q19.All.
+type Q19Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+	V16 *T16
+	V17 *T17
+	V18 *T18
+	V19 *T19
+}
+type Q19Option struct {
+	Optional [19]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [19]bool        // Query components to stamp as changed for every row visited
+}
+type Q20[ID Int, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, T20 any] struct {
+	storage    *Storage[ID]
+	cache      queryCache
+	Components [20]int
+	Errors     []error
+}
+
+// Q20Row holds the components of one entity yielded by Q20.All.
+type Q20Row[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, T20 any] struct {
+	V1  *T1
+	V2  *T2
+	V3  *T3
+	V4  *T4
+	V5  *T5
+	V6  *T6
+	V7  *T7
+	V8  *T8
+	V9  *T9
+	V10 *T10
+	V11 *T11
+	V12 *T12
+	V13 *T13
+	V14 *T14
+	V15 *T15
+	V16 *T16
+	V17 *T17
+	V18 *T18
+	V19 *T19
+	V20 *T20
+}
+type Q20Option struct {
+	Optional [20]bool
+	Stop     *bool
+	Hash     *ComponentHash
+	With     []ComponentType // Components a compound must have
+	Without  []ComponentType // Components a compound must not have
+	AnyOf    []ComponentType // A compound must have at least one of these
+	Added    []ComponentType // Components that must have been added after Since
+	Changed  []ComponentType // Components that must have been written after Since
+	Since    uint64          // Tick returned by Storage.Tick on the previous run
+	Mark     [20]bool        // Query components to stamp as changed for every row visited
+}
+
+func Query1[T1 any, ID Int](storage *Storage[ID]) *Q1[ID, T1] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q1[ID, T1]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query2[T1 any, T2 any, ID Int](storage *Storage[ID]) *Q2[ID, T1, T2] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q2[ID, T1, T2]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query3[T1 any, T2 any, T3 any, ID Int](storage *Storage[ID]) *Q3[ID, T1, T2, T3] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q3[ID, T1, T2, T3]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query4[T1 any, T2 any, T3 any, T4 any, ID Int](storage *Storage[ID]) *Q4[ID, T1, T2, T3, T4] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q4[ID, T1, T2, T3, T4]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query5[T1 any, T2 any, T3 any, T4 any, T5 any, ID Int](storage *Storage[ID]) *Q5[ID, T1, T2, T3, T4, T5] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q5[ID, T1, T2, T3, T4, T5]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query6[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, ID Int](storage *Storage[ID]) *Q6[ID, T1, T2, T3, T4, T5, T6] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q6[ID, T1, T2, T3, T4, T5, T6]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query7[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, ID Int](storage *Storage[ID]) *Q7[ID, T1, T2, T3, T4, T5, T6, T7] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q7[ID, T1, T2, T3, T4, T5, T6, T7]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query8[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, ID Int](storage *Storage[ID]) *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query9[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, ID Int](storage *Storage[ID]) *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query10[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, ID Int](storage *Storage[ID]) *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query11[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, ID Int](storage *Storage[ID]) *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query12[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, ID Int](storage *Storage[ID]) *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query13[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, ID Int](storage *Storage[ID]) *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query14[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, ID Int](storage *Storage[ID]) *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query15[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, ID Int](storage *Storage[ID]) *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query16[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, ID Int](storage *Storage[ID]) *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T16]()
+		id, ok := storage.getComponent(t)
+		q.Components[15] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query17[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, ID Int](storage *Storage[ID]) *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T16]()
+		id, ok := storage.getComponent(t)
+		q.Components[15] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T17]()
+		id, ok := storage.getComponent(t)
+		q.Components[16] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query18[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, ID Int](storage *Storage[ID]) *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T16]()
+		id, ok := storage.getComponent(t)
+		q.Components[15] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T17]()
+		id, ok := storage.getComponent(t)
+		q.Components[16] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T18]()
+		id, ok := storage.getComponent(t)
+		q.Components[17] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query19[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, ID Int](storage *Storage[ID]) *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T16]()
+		id, ok := storage.getComponent(t)
+		q.Components[15] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T17]()
+		id, ok := storage.getComponent(t)
+		q.Components[16] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T18]()
+		id, ok := storage.getComponent(t)
+		q.Components[17] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T19]()
+		id, ok := storage.getComponent(t)
+		q.Components[18] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 19 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func Query20[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, T20 any, ID Int](storage *Storage[ID]) *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20] {
+	storage.rlock()
+	defer storage.runlock()
+	q := &Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]{storage: storage}
+	{
+		t := typeOf[T1]()
+		id, ok := storage.getComponent(t)
+		q.Components[0] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T2]()
+		id, ok := storage.getComponent(t)
+		q.Components[1] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T3]()
+		id, ok := storage.getComponent(t)
+		q.Components[2] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T4]()
+		id, ok := storage.getComponent(t)
+		q.Components[3] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T5]()
+		id, ok := storage.getComponent(t)
+		q.Components[4] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T6]()
+		id, ok := storage.getComponent(t)
+		q.Components[5] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T7]()
+		id, ok := storage.getComponent(t)
+		q.Components[6] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T8]()
+		id, ok := storage.getComponent(t)
+		q.Components[7] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T9]()
+		id, ok := storage.getComponent(t)
+		q.Components[8] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T10]()
+		id, ok := storage.getComponent(t)
+		q.Components[9] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T11]()
+		id, ok := storage.getComponent(t)
+		q.Components[10] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T12]()
+		id, ok := storage.getComponent(t)
+		q.Components[11] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T13]()
+		id, ok := storage.getComponent(t)
+		q.Components[12] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T14]()
+		id, ok := storage.getComponent(t)
+		q.Components[13] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T15]()
+		id, ok := storage.getComponent(t)
+		q.Components[14] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T16]()
+		id, ok := storage.getComponent(t)
+		q.Components[15] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T17]()
+		id, ok := storage.getComponent(t)
+		q.Components[16] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T18]()
+		id, ok := storage.getComponent(t)
+		q.Components[17] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T19]()
+		id, ok := storage.getComponent(t)
+		q.Components[18] = id
 		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+			q.Errors = append(q.Errors, fmt.Errorf("component 19 \"%s\" does not exist", t))
+		}
+	}
+	{
+		t := typeOf[T20]()
+		id, ok := storage.getComponent(t)
+		q.Components[19] = id
+		if !ok {
+			q.Errors = append(q.Errors, fmt.Errorf("component 20 \"%s\" does not exist", t))
+		}
+	}
+	return q
+}
+
+func (q *Q1[ID, T1]) Each(fn func(ID, *T1), queryOptions ...Q1Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q1[ID, T1]) EachHierarchy(fn func(ID, *T1), queryOptions ...Q1Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q1[ID, T1]) each(fn func(ID, *T1), hierarchy bool, queryOptions []Q1Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q1Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q2[ID, T1, T2]) Each(fn func(ID, *T1, *T2), queryOptions ...Q2Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q2[ID, T1, T2]) EachHierarchy(fn func(ID, *T1, *T2), queryOptions ...Q2Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q2[ID, T1, T2]) each(fn func(ID, *T1, *T2), hierarchy bool, queryOptions []Q2Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q2Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q3[ID, T1, T2, T3]) Each(fn func(ID, *T1, *T2, *T3), queryOptions ...Q3Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q3[ID, T1, T2, T3]) EachHierarchy(fn func(ID, *T1, *T2, *T3), queryOptions ...Q3Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q3[ID, T1, T2, T3]) each(fn func(ID, *T1, *T2, *T3), hierarchy bool, queryOptions []Q3Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q3Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q4[ID, T1, T2, T3, T4]) Each(fn func(ID, *T1, *T2, *T3, *T4), queryOptions ...Q4Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q4[ID, T1, T2, T3, T4]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4), queryOptions ...Q4Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q4[ID, T1, T2, T3, T4]) each(fn func(ID, *T1, *T2, *T3, *T4), hierarchy bool, queryOptions []Q4Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q4Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q5[ID, T1, T2, T3, T4, T5]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5), queryOptions ...Q5Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q5[ID, T1, T2, T3, T4, T5]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5), queryOptions ...Q5Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q5[ID, T1, T2, T3, T4, T5]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5), hierarchy bool, queryOptions []Q5Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q5Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6), queryOptions ...Q6Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6), queryOptions ...Q6Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6), hierarchy bool, queryOptions []Q6Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q6Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), queryOptions ...Q7Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), queryOptions ...Q7Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), hierarchy bool, queryOptions []Q7Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q7Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), queryOptions ...Q8Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), queryOptions ...Q8Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), hierarchy bool, queryOptions []Q8Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q8Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), queryOptions ...Q9Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), queryOptions ...Q9Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), hierarchy bool, queryOptions []Q9Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q9Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), queryOptions ...Q10Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), queryOptions ...Q10Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), hierarchy bool, queryOptions []Q10Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q10Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), queryOptions ...Q11Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), queryOptions ...Q11Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), hierarchy bool, queryOptions []Q11Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q11Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), queryOptions ...Q12Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), queryOptions ...Q12Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), hierarchy bool, queryOptions []Q12Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q12Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), queryOptions ...Q13Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), queryOptions ...Q13Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), hierarchy bool, queryOptions []Q13Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q13Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), queryOptions ...Q14Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), queryOptions ...Q14Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), hierarchy bool, queryOptions []Q14Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q14Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), queryOptions ...Q15Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), queryOptions ...Q15Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), hierarchy bool, queryOptions []Q15Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q15Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), queryOptions ...Q16Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), queryOptions ...Q16Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), hierarchy bool, queryOptions []Q16Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q16Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+		v16s := columnData[T16](compound, match.columns[15])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), queryOptions ...Q17Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), queryOptions ...Q17Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), hierarchy bool, queryOptions []Q17Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q17Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+		v16s := columnData[T16](compound, match.columns[15])
+		v17s := columnData[T17](compound, match.columns[16])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), queryOptions ...Q18Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), queryOptions ...Q18Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), hierarchy bool, queryOptions []Q18Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q18Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+		if match.columns[17] < 0 && !options.Optional[17] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+		v16s := columnData[T16](compound, match.columns[15])
+		v17s := columnData[T17](compound, match.columns[16])
+		v18s := columnData[T18](compound, match.columns[17])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), queryOptions ...Q19Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), queryOptions ...Q19Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), hierarchy bool, queryOptions []Q19Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q19Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
 		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+		if match.columns[17] < 0 && !options.Optional[17] {
+			continue
+		}
+		if match.columns[18] < 0 && !options.Optional[18] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+		v16s := columnData[T16](compound, match.columns[15])
+		v17s := columnData[T17](compound, match.columns[16])
+		v18s := columnData[T18](compound, match.columns[17])
+		v19s := columnData[T19](compound, match.columns[18])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), queryOptions ...Q20Option) {
+	q.each(fn, false, queryOptions)
+}
+
+// EachHierarchy is Each visiting parents before their children in the ChildOf hierarchy.
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) EachHierarchy(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), queryOptions ...Q20Option) {
+	q.each(fn, true, queryOptions)
+}
+
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), hierarchy bool, queryOptions []Q20Option) {
+	// Skip if there is an error
+	if q.Errors != nil {
+		return
+	}
+	var options Q20Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	// Purge removed rows, then run the callbacks under the read lock only
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], false)
+	defer q.storage.runlock()
+	if hierarchy {
+		matches = q.storage.hierarchyOrder(matches)
+	}
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+		if match.columns[17] < 0 && !options.Optional[17] {
+			continue
+		}
+		if match.columns[18] < 0 && !options.Optional[18] {
+			continue
+		}
+		if match.columns[19] < 0 && !options.Optional[19] {
+			continue
+		}
+
+		v1s := columnData[T1](compound, match.columns[0])
+		v2s := columnData[T2](compound, match.columns[1])
+		v3s := columnData[T3](compound, match.columns[2])
+		v4s := columnData[T4](compound, match.columns[3])
+		v5s := columnData[T5](compound, match.columns[4])
+		v6s := columnData[T6](compound, match.columns[5])
+		v7s := columnData[T7](compound, match.columns[6])
+		v8s := columnData[T8](compound, match.columns[7])
+		v9s := columnData[T9](compound, match.columns[8])
+		v10s := columnData[T10](compound, match.columns[9])
+		v11s := columnData[T11](compound, match.columns[10])
+		v12s := columnData[T12](compound, match.columns[11])
+		v13s := columnData[T13](compound, match.columns[12])
+		v14s := columnData[T14](compound, match.columns[13])
+		v15s := columnData[T15](compound, match.columns[14])
+		v16s := columnData[T16](compound, match.columns[15])
+		v17s := columnData[T17](compound, match.columns[16])
+		v18s := columnData[T18](compound, match.columns[17])
+		v19s := columnData[T19](compound, match.columns[18])
+		v20s := columnData[T20](compound, match.columns[19])
+
+		removed := compound.Removed
+		for idx, id := range compound.Entitys {
+			if removed != nil && removed[idx] || changes.active && !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx), getOptional(v20s, idx))
+			if mark {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+			if *options.Stop {
+				return
+			}
+		}
+	}
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q1[ID, T1]) EachCmd(fn func(*Commands[ID], ID, *T1), queryOptions ...Q1Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1) { fn(cmd, id, v1) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q2[ID, T1, T2]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2), queryOptions ...Q2Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2) { fn(cmd, id, v1, v2) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q3[ID, T1, T2, T3]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3), queryOptions ...Q3Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3) { fn(cmd, id, v1, v2, v3) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q4[ID, T1, T2, T3, T4]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4), queryOptions ...Q4Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4) { fn(cmd, id, v1, v2, v3, v4) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q5[ID, T1, T2, T3, T4, T5]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5), queryOptions ...Q5Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5) { fn(cmd, id, v1, v2, v3, v4, v5) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6), queryOptions ...Q6Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6) { fn(cmd, id, v1, v2, v3, v4, v5, v6) }, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), queryOptions ...Q7Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), queryOptions ...Q8Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), queryOptions ...Q9Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), queryOptions ...Q10Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), queryOptions ...Q11Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), queryOptions ...Q12Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), queryOptions ...Q13Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), queryOptions ...Q14Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), queryOptions ...Q15Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), queryOptions ...Q16Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), queryOptions ...Q17Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), queryOptions ...Q18Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), queryOptions ...Q19Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18, v19 *T19) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// EachCmd runs Each with a command buffer that is applied once iteration is done.
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) EachCmd(fn func(*Commands[ID], ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), queryOptions ...Q20Option) {
+	cmd := q.storage.Commands()
+	q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18, v19 *T19, v20 *T20) {
+		fn(cmd, id, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, v20)
+	}, queryOptions...)
+	cmd.Apply()
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q1[ID, T1]) All(queryOptions ...Q1Option) iter.Seq2[ID, Q1Row[T1]] {
+	return func(yield func(ID, Q1Row[T1]) bool) {
+		var options Q1Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1) {
+			if !yield(id, Q1Row[T1]{v1}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q2[ID, T1, T2]) All(queryOptions ...Q2Option) iter.Seq2[ID, Q2Row[T1, T2]] {
+	return func(yield func(ID, Q2Row[T1, T2]) bool) {
+		var options Q2Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2) {
+			if !yield(id, Q2Row[T1, T2]{v1, v2}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q3[ID, T1, T2, T3]) All(queryOptions ...Q3Option) iter.Seq2[ID, Q3Row[T1, T2, T3]] {
+	return func(yield func(ID, Q3Row[T1, T2, T3]) bool) {
+		var options Q3Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3) {
+			if !yield(id, Q3Row[T1, T2, T3]{v1, v2, v3}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q4[ID, T1, T2, T3, T4]) All(queryOptions ...Q4Option) iter.Seq2[ID, Q4Row[T1, T2, T3, T4]] {
+	return func(yield func(ID, Q4Row[T1, T2, T3, T4]) bool) {
+		var options Q4Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4) {
+			if !yield(id, Q4Row[T1, T2, T3, T4]{v1, v2, v3, v4}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q5[ID, T1, T2, T3, T4, T5]) All(queryOptions ...Q5Option) iter.Seq2[ID, Q5Row[T1, T2, T3, T4, T5]] {
+	return func(yield func(ID, Q5Row[T1, T2, T3, T4, T5]) bool) {
+		var options Q5Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5) {
+			if !yield(id, Q5Row[T1, T2, T3, T4, T5]{v1, v2, v3, v4, v5}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) All(queryOptions ...Q6Option) iter.Seq2[ID, Q6Row[T1, T2, T3, T4, T5, T6]] {
+	return func(yield func(ID, Q6Row[T1, T2, T3, T4, T5, T6]) bool) {
+		var options Q6Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6) {
+			if !yield(id, Q6Row[T1, T2, T3, T4, T5, T6]{v1, v2, v3, v4, v5, v6}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) All(queryOptions ...Q7Option) iter.Seq2[ID, Q7Row[T1, T2, T3, T4, T5, T6, T7]] {
+	return func(yield func(ID, Q7Row[T1, T2, T3, T4, T5, T6, T7]) bool) {
+		var options Q7Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7) {
+			if !yield(id, Q7Row[T1, T2, T3, T4, T5, T6, T7]{v1, v2, v3, v4, v5, v6, v7}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) All(queryOptions ...Q8Option) iter.Seq2[ID, Q8Row[T1, T2, T3, T4, T5, T6, T7, T8]] {
+	return func(yield func(ID, Q8Row[T1, T2, T3, T4, T5, T6, T7, T8]) bool) {
+		var options Q8Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8) {
+			if !yield(id, Q8Row[T1, T2, T3, T4, T5, T6, T7, T8]{v1, v2, v3, v4, v5, v6, v7, v8}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) All(queryOptions ...Q9Option) iter.Seq2[ID, Q9Row[T1, T2, T3, T4, T5, T6, T7, T8, T9]] {
+	return func(yield func(ID, Q9Row[T1, T2, T3, T4, T5, T6, T7, T8, T9]) bool) {
+		var options Q9Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9) {
+			if !yield(id, Q9Row[T1, T2, T3, T4, T5, T6, T7, T8, T9]{v1, v2, v3, v4, v5, v6, v7, v8, v9}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) All(queryOptions ...Q10Option) iter.Seq2[ID, Q10Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]] {
+	return func(yield func(ID, Q10Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) bool) {
+		var options Q10Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10) {
+			if !yield(id, Q10Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) All(queryOptions ...Q11Option) iter.Seq2[ID, Q11Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]] {
+	return func(yield func(ID, Q11Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) bool) {
+		var options Q11Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11) {
+			if !yield(id, Q11Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) All(queryOptions ...Q12Option) iter.Seq2[ID, Q12Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]] {
+	return func(yield func(ID, Q12Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) bool) {
+		var options Q12Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12) {
+			if !yield(id, Q12Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) All(queryOptions ...Q13Option) iter.Seq2[ID, Q13Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]] {
+	return func(yield func(ID, Q13Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) bool) {
+		var options Q13Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13) {
+			if !yield(id, Q13Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) All(queryOptions ...Q14Option) iter.Seq2[ID, Q14Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]] {
+	return func(yield func(ID, Q14Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) bool) {
+		var options Q14Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14) {
+			if !yield(id, Q14Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) All(queryOptions ...Q15Option) iter.Seq2[ID, Q15Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]] {
+	return func(yield func(ID, Q15Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) bool) {
+		var options Q15Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15) {
+			if !yield(id, Q15Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) All(queryOptions ...Q16Option) iter.Seq2[ID, Q16Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]] {
+	return func(yield func(ID, Q16Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) bool) {
+		var options Q16Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16) {
+			if !yield(id, Q16Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) All(queryOptions ...Q17Option) iter.Seq2[ID, Q17Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]] {
+	return func(yield func(ID, Q17Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) bool) {
+		var options Q17Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17) {
+			if !yield(id, Q17Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) All(queryOptions ...Q18Option) iter.Seq2[ID, Q18Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]] {
+	return func(yield func(ID, Q18Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) bool) {
+		var options Q18Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18) {
+			if !yield(id, Q18Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) All(queryOptions ...Q19Option) iter.Seq2[ID, Q19Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]] {
+	return func(yield func(ID, Q19Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) bool) {
+		var options Q19Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18, v19 *T19) {
+			if !yield(id, Q19Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// All returns an iterator over the entities matching the query. Breaking out of the loop stops the query
+// the same way Stop does. The loop body runs under the same lock as an Each callback.
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) All(queryOptions ...Q20Option) iter.Seq2[ID, Q20Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]] {
+	return func(yield func(ID, Q20Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) bool) {
+		var options Q20Option
+		if len(queryOptions) == 1 {
+			options = queryOptions[0]
+		}
+		options.Stop = new(bool)
+		q.Each(func(id ID, v1 *T1, v2 *T2, v3 *T3, v4 *T4, v5 *T5, v6 *T6, v7 *T7, v8 *T8, v9 *T9, v10 *T10, v11 *T11, v12 *T12, v13 *T13, v14 *T14, v15 *T15, v16 *T16, v17 *T17, v18 *T18, v19 *T19, v20 *T20) {
+			if !yield(id, Q20Row[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]{v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15, v16, v17, v18, v19, v20}) {
+				*options.Stop = true
+			}
+		}, options)
+	}
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q1[ID, T1]) ParEach(pool *Pool, fn func(ID, *T1), queryOptions ...Q1Option) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q1Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
-}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
 
-func Query8[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, ID Int](storage *Storage[ID]) *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q2[ID, T1, T2]) ParEach(pool *Pool, fn func(ID, *T1, *T2), queryOptions ...Q2Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q2Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q3[ID, T1, T2, T3]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3), queryOptions ...Q3Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
-		}
+	var options Q3Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
-}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
 
-func Query9[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, ID Int](storage *Storage[ID]) *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q4[ID, T1, T2, T3, T4]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4), queryOptions ...Q4Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q4Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q5[ID, T1, T2, T3, T4, T5]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5), queryOptions ...Q5Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+	var options Q5Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
-}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
 
-func Query10[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, ID Int](storage *Storage[ID]) *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6), queryOptions ...Q6Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q6Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query11[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, ID Int](storage *Storage[ID]) *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
-	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), queryOptions ...Q7Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+	var options Q7Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
-}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
 
-func Query12[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, ID Int](storage *Storage[ID]) *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), queryOptions ...Q8Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q8Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query13[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, ID Int](storage *Storage[ID]) *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), queryOptions ...Q9Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q9Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query14[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, ID Int](storage *Storage[ID]) *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), queryOptions ...Q10Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q10Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query15[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, ID Int](storage *Storage[ID]) *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), queryOptions ...Q11Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q11Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query16[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, ID Int](storage *Storage[ID]) *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), queryOptions ...Q12Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q12Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T16]()
-		id, ok := storage.getComponent(name)
-		q.Components[15] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query17[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, ID Int](storage *Storage[ID]) *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
-	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), queryOptions ...Q13Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+	var options Q13Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-	}
-	{
-		name := typeName[T16]()
-		id, ok := storage.getComponent(name)
-		q.Components[15] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", name))
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
 		}
-	}
-	{
-		name := typeName[T17]()
-		id, ok := storage.getComponent(name)
-		q.Components[16] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", name))
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
-}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
 
-func Query18[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, ID Int](storage *Storage[ID]) *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
+	})
+}
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), queryOptions ...Q14Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q14Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
 		}
-	}
-	{
-		name := typeName[T16]()
-		id, ok := storage.getComponent(name)
-		q.Components[15] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", name))
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
 		}
-	}
-	{
-		name := typeName[T17]()
-		id, ok := storage.getComponent(name)
-		q.Components[16] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", name))
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T18]()
-		id, ok := storage.getComponent(name)
-		q.Components[17] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query19[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, ID Int](storage *Storage[ID]) *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), queryOptions ...Q15Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+	var options Q15Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
 		}
-	}
-	{
-		name := typeName[T16]()
-		id, ok := storage.getComponent(name)
-		q.Components[15] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", name))
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
 		}
-	}
-	{
-		name := typeName[T17]()
-		id, ok := storage.getComponent(name)
-		q.Components[16] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", name))
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
 		}
-	}
-	{
-		name := typeName[T18]()
-		id, ok := storage.getComponent(name)
-		q.Components[17] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", name))
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	{
-		name := typeName[T19]()
-		id, ok := storage.getComponent(name)
-		q.Components[18] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 19 \"%s\" does not exist", name))
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
 		}
-	}
-	return q
+	})
 }
 
-func Query20[T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, T8 any, T9 any, T10 any, T11 any, T12 any, T13 any, T14 any, T15 any, T16 any, T17 any, T18 any, T19 any, T20 any, ID Int](storage *Storage[ID]) *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20] {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	q := &Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]{storage: storage}
-	{
-		name := typeName[T1]()
-		id, ok := storage.getComponent(name)
-		q.Components[0] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 1 \"%s\" does not exist", name))
-		}
-	}
-	{
-		name := typeName[T2]()
-		id, ok := storage.getComponent(name)
-		q.Components[1] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 2 \"%s\" does not exist", name))
-		}
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), queryOptions ...Q16Option) {
+	if q.Errors != nil {
+		return
 	}
-	{
-		name := typeName[T3]()
-		id, ok := storage.getComponent(name)
-		q.Components[2] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 3 \"%s\" does not exist", name))
-		}
+	var options Q16Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	{
-		name := typeName[T4]()
-		id, ok := storage.getComponent(name)
-		q.Components[3] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 4 \"%s\" does not exist", name))
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-	}
-	{
-		name := typeName[T5]()
-		id, ok := storage.getComponent(name)
-		q.Components[4] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 5 \"%s\" does not exist", name))
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-	}
-	{
-		name := typeName[T6]()
-		id, ok := storage.getComponent(name)
-		q.Components[5] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 6 \"%s\" does not exist", name))
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
 		}
-	}
-	{
-		name := typeName[T7]()
-		id, ok := storage.getComponent(name)
-		q.Components[6] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 7 \"%s\" does not exist", name))
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	{
-		name := typeName[T8]()
-		id, ok := storage.getComponent(name)
-		q.Components[7] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 8 \"%s\" does not exist", name))
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	{
-		name := typeName[T9]()
-		id, ok := storage.getComponent(name)
-		q.Components[8] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 9 \"%s\" does not exist", name))
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	{
-		name := typeName[T10]()
-		id, ok := storage.getComponent(name)
-		q.Components[9] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 10 \"%s\" does not exist", name))
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	{
-		name := typeName[T11]()
-		id, ok := storage.getComponent(name)
-		q.Components[10] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 11 \"%s\" does not exist", name))
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-	}
-	{
-		name := typeName[T12]()
-		id, ok := storage.getComponent(name)
-		q.Components[11] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 12 \"%s\" does not exist", name))
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-	}
-	{
-		name := typeName[T13]()
-		id, ok := storage.getComponent(name)
-		q.Components[12] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 13 \"%s\" does not exist", name))
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-	}
-	{
-		name := typeName[T14]()
-		id, ok := storage.getComponent(name)
-		q.Components[13] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 14 \"%s\" does not exist", name))
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-	}
-	{
-		name := typeName[T15]()
-		id, ok := storage.getComponent(name)
-		q.Components[14] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 15 \"%s\" does not exist", name))
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-	}
-	{
-		name := typeName[T16]()
-		id, ok := storage.getComponent(name)
-		q.Components[15] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 16 \"%s\" does not exist", name))
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
 		}
-	}
-	{
-		name := typeName[T17]()
-		id, ok := storage.getComponent(name)
-		q.Components[16] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 17 \"%s\" does not exist", name))
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
 		}
-	}
-	{
-		name := typeName[T18]()
-		id, ok := storage.getComponent(name)
-		q.Components[17] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 18 \"%s\" does not exist", name))
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
 		}
-	}
-	{
-		name := typeName[T19]()
-		id, ok := storage.getComponent(name)
-		q.Components[18] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 19 \"%s\" does not exist", name))
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
 		}
-	}
-	{
-		name := typeName[T20]()
-		id, ok := storage.getComponent(name)
-		q.Components[19] = id
-		if !ok {
-			q.Errors = append(q.Errors, fmt.Errorf("component 20 \"%s\" does not exist", name))
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
 		}
+
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
 	}
-	return q
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+		v16s := columnData[T16](compound, chunk.match.columns[15])
+
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
+				continue
+			}
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
+			}
+		}
+	})
 }
 
-func (q *Q1[ID, T1]) Each(fn func(ID, *T1), queryOptions ...Q1Option) {
-	// Skip if there is an error
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), queryOptions ...Q17Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q1Option
+	var options Q17Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
 			continue
 		}
-		// Select components
-		var v1s []T1
 
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+	}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+		v16s := columnData[T16](compound, chunk.match.columns[15])
+		v17s := columnData[T17](compound, chunk.match.columns[16])
 
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx))
-			if *options.Stop {
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
 				continue
 			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
+	})
 }
-func (q *Q2[ID, T1, T2]) Each(fn func(ID, *T1, *T2), queryOptions ...Q2Option) {
-	// Skip if there is an error
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), queryOptions ...Q18Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q2Option
+	var options Q18Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+		if match.columns[17] < 0 && !options.Optional[17] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
 
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+	}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+		v16s := columnData[T16](compound, chunk.match.columns[15])
+		v17s := columnData[T17](compound, chunk.match.columns[16])
+		v18s := columnData[T18](compound, chunk.match.columns[17])
 
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx))
-			if *options.Stop {
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
 				continue
 			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
+	})
 }
-func (q *Q3[ID, T1, T2, T3]) Each(fn func(ID, *T1, *T2, *T3), queryOptions ...Q3Option) {
-	// Skip if there is an error
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), queryOptions ...Q19Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q3Option
+	var options Q19Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx))
-			if *options.Stop {
-				continue
-			}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
-}
-func (q *Q4[ID, T1, T2, T3, T4]) Each(fn func(ID, *T1, *T2, *T3, *T4), queryOptions ...Q4Option) {
-	// Skip if there is an error
-	if q.Errors != nil {
-		return
-	}
-	var options Q4Option
-	if len(queryOptions) == 1 {
-		options = queryOptions[0]
-	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[17] < 0 && !options.Optional[17] {
+			continue
+		}
+		if match.columns[18] < 0 && !options.Optional[18] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
 
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+	}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+		v16s := columnData[T16](compound, chunk.match.columns[15])
+		v17s := columnData[T17](compound, chunk.match.columns[16])
+		v18s := columnData[T18](compound, chunk.match.columns[17])
+		v19s := columnData[T19](compound, chunk.match.columns[18])
 
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx))
-			if *options.Stop {
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
 				continue
 			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
+	})
 }
-func (q *Q5[ID, T1, T2, T3, T4, T5]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5), queryOptions ...Q5Option) {
-	// Skip if there is an error
+
+// ParEach runs fn for every matching entity on the workers of pool, or a shared default pool when nil.
+// The rows of each compound are split into contiguous chunks and every chunk is run by a single worker,
+// so each row is visited exactly once. fn runs concurrently with itself and may only modify the components
+// it is passed. The storage is read locked for the whole call and Stop is ignored.
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) ParEach(pool *Pool, fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), queryOptions ...Q20Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q5Option
+	var options Q20Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	pool = poolOrDefault(pool)
+	q.storage.rlock()
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	changes := q.storage.changeResolve(options.Added, options.Changed, options.Since)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	var chunks []parChunk
+	for _, match := range q.storage.queryCacheMatches(&q.cache, q.Components[:], options.Optional[:]) {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) || !changes.bind(compound.Components) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx))
-			if *options.Stop {
-				continue
-			}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
-}
-func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6), queryOptions ...Q6Option) {
-	// Skip if there is an error
-	if q.Errors != nil {
-		return
-	}
-	var options Q6Option
-	if len(queryOptions) == 1 {
-		options = queryOptions[0]
-	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
-	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[16] < 0 && !options.Optional[16] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[17] < 0 && !options.Optional[17] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[18] < 0 && !options.Optional[18] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[19] < 0 && !options.Optional[19] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
 
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
+		chunks = parChunks(chunks, match, len(compound.Entitys), pool.chunkSize)
+	}
+	pool.run(len(chunks), func(i int) {
+		chunk := chunks[i]
+		compound := q.storage.Compounds[chunk.match.compound]
+		v1s := columnData[T1](compound, chunk.match.columns[0])
+		v2s := columnData[T2](compound, chunk.match.columns[1])
+		v3s := columnData[T3](compound, chunk.match.columns[2])
+		v4s := columnData[T4](compound, chunk.match.columns[3])
+		v5s := columnData[T5](compound, chunk.match.columns[4])
+		v6s := columnData[T6](compound, chunk.match.columns[5])
+		v7s := columnData[T7](compound, chunk.match.columns[6])
+		v8s := columnData[T8](compound, chunk.match.columns[7])
+		v9s := columnData[T9](compound, chunk.match.columns[8])
+		v10s := columnData[T10](compound, chunk.match.columns[9])
+		v11s := columnData[T11](compound, chunk.match.columns[10])
+		v12s := columnData[T12](compound, chunk.match.columns[11])
+		v13s := columnData[T13](compound, chunk.match.columns[12])
+		v14s := columnData[T14](compound, chunk.match.columns[13])
+		v15s := columnData[T15](compound, chunk.match.columns[14])
+		v16s := columnData[T16](compound, chunk.match.columns[15])
+		v17s := columnData[T17](compound, chunk.match.columns[16])
+		v18s := columnData[T18](compound, chunk.match.columns[17])
+		v19s := columnData[T19](compound, chunk.match.columns[18])
+		v20s := columnData[T20](compound, chunk.match.columns[19])
 
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx))
-			if *options.Stop {
+		changes := changes.fork()
+		changes.bind(compound.Components)
+		for idx := chunk.start; idx < chunk.end; idx++ {
+			id := compound.Entitys[idx]
+			if compound.rowRemoved(idx) || !changes.row(idx) {
 				continue
 			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx), getOptional(v20s, idx))
+			if mark {
+				markRow(compound, chunk.match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
-	}
-	q.storage.lock.Unlock()
+	})
 }
-func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7), queryOptions ...Q7Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q1[ID, T1]) EachChunk(fn func([]ID, []T1), queryOptions ...Q1Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q7Option
+	var options Q1Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
-			continue
-		}
-		if v3s == nil && !options.Optional[2] {
-			continue
-		}
-		if v4s == nil && !options.Optional[3] {
-			continue
-		}
-		if v5s == nil && !options.Optional[4] {
-			continue
-		}
-		if v6s == nil && !options.Optional[5] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
+		if *options.Stop {
+			return
+		}
 	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q2[ID, T1, T2]) EachChunk(fn func([]ID, []T1, []T2), queryOptions ...Q2Option) {
+	if q.Errors != nil {
 		return
 	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+	var options Q2Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx))
-			if *options.Stop {
-				continue
-			}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
+		if *options.Stop {
+			return
+		}
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8), queryOptions ...Q8Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q3[ID, T1, T2, T3]) EachChunk(fn func([]ID, []T1, []T2, []T3), queryOptions ...Q3Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q8Option
+	var options Q3Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
-			continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+		}
+		if *options.Stop {
+			return
 		}
-		if v7s == nil && !options.Optional[6] {
+	}
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q4[ID, T1, T2, T3, T4]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4), queryOptions ...Q4Option) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q4Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
 
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9), queryOptions ...Q9Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q5[ID, T1, T2, T3, T4, T5]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5), queryOptions ...Q5Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q9Option
+	var options Q5Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
+			}
+		}
+		if *options.Stop {
+			return
+		}
+	}
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q6[ID, T1, T2, T3, T4, T5, T6]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6), queryOptions ...Q6Option) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q6Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx))
-			if *options.Stop {
-				break LOOP
-			}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
 
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10), queryOptions ...Q10Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q7[ID, T1, T2, T3, T4, T5, T6, T7]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7), queryOptions ...Q7Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q10Option
+	var options Q7Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
-			continue
-		}
-		if v3s == nil && !options.Optional[2] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
+		if *options.Stop {
+			return
+		}
+	}
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q8[ID, T1, T2, T3, T4, T5, T6, T7, T8]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8), queryOptions ...Q8Option) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q8Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
 		return
 	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
 
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11), queryOptions ...Q11Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q9[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9), queryOptions ...Q9Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q11Option
+	var options Q9Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12), queryOptions ...Q12Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q10[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10), queryOptions ...Q10Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q12Option
+	var options Q10Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13), queryOptions ...Q13Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q11[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11), queryOptions ...Q11Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q13Option
+	var options Q11Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
-			continue
-		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx))
-			if *options.Stop {
-				break LOOP
-			}
-		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14), queryOptions ...Q14Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q12[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12), queryOptions ...Q12Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q14Option
+	var options Q12Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
-			continue
-		}
-		if v2s == nil && !options.Optional[1] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
+		if *options.Stop {
+			return
+		}
 	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q13[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13), queryOptions ...Q13Option) {
+	if q.Errors != nil {
 		return
 	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+	var options Q13Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
+	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
 
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15), queryOptions ...Q15Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q14[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14), queryOptions ...Q14Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q15Option
+	var options Q14Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16), queryOptions ...Q16Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q15[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15), queryOptions ...Q15Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q16Option
+	var options Q15Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
-	if options.Stop == nil {
-		options.Stop = new(bool)
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v16s == nil && !options.Optional[15] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17), queryOptions ...Q17Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q16[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15, []T16), queryOptions ...Q16Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q17Option
+	var options Q16Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v16s == nil && !options.Optional[15] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-		if v17s == nil && !options.Optional[16] {
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]), columnData[T16](compound, match.columns[15]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-
+			return
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
-		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18), queryOptions ...Q18Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q17[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15, []T16, []T17), queryOptions ...Q17Option) {
 	if q.Errors != nil {
 		return
 	}
-	var options Q18Option
+	var options Q17Option
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
-
-		}
-		if v1s == nil && !options.Optional[0] {
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v16s == nil && !options.Optional[15] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-		if v17s == nil && !options.Optional[16] {
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
-		if v18s == nil && !options.Optional[17] {
+		if match.columns[16] < 0 && !options.Optional[16] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]), columnData[T16](compound, match.columns[15]), columnData[T17](compound, match.columns[16]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
+		if *options.Stop {
+			return
+		}
+	}
+}
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q18[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15, []T16, []T17, []T18), queryOptions ...Q18Option) {
+	if q.Errors != nil {
+		return
+	}
+	var options Q18Option
+	if len(queryOptions) == 1 {
+		options = queryOptions[0]
 	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
 		return
 	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
-		if *options.Stop {
+	if options.Stop == nil {
+		options.Stop = new(bool)
+	}
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
+		}
+		if match.columns[0] < 0 && !options.Optional[0] {
+			continue
+		}
+		if match.columns[1] < 0 && !options.Optional[1] {
+			continue
+		}
+		if match.columns[2] < 0 && !options.Optional[2] {
+			continue
+		}
+		if match.columns[3] < 0 && !options.Optional[3] {
+			continue
+		}
+		if match.columns[4] < 0 && !options.Optional[4] {
+			continue
+		}
+		if match.columns[5] < 0 && !options.Optional[5] {
+			continue
+		}
+		if match.columns[6] < 0 && !options.Optional[6] {
+			continue
+		}
+		if match.columns[7] < 0 && !options.Optional[7] {
+			continue
+		}
+		if match.columns[8] < 0 && !options.Optional[8] {
+			continue
+		}
+		if match.columns[9] < 0 && !options.Optional[9] {
+			continue
+		}
+		if match.columns[10] < 0 && !options.Optional[10] {
+			continue
+		}
+		if match.columns[11] < 0 && !options.Optional[11] {
+			continue
+		}
+		if match.columns[12] < 0 && !options.Optional[12] {
+			continue
+		}
+		if match.columns[13] < 0 && !options.Optional[13] {
+			continue
+		}
+		if match.columns[14] < 0 && !options.Optional[14] {
+			continue
+		}
+		if match.columns[15] < 0 && !options.Optional[15] {
+			continue
+		}
+		if match.columns[16] < 0 && !options.Optional[16] {
+			continue
+		}
+		if match.columns[17] < 0 && !options.Optional[17] {
 			continue
 		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
 
+		if len(compound.Entitys) == 0 {
+			continue
 		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx))
-			if *options.Stop {
-				continue
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]), columnData[T16](compound, match.columns[15]), columnData[T17](compound, match.columns[16]), columnData[T18](compound, match.columns[17]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+		if *options.Stop {
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19), queryOptions ...Q19Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15, []T16, []T17, []T18, []T19), queryOptions ...Q19Option) {
 	if q.Errors != nil {
 		return
 	}
@@ -6816,347 +12654,101 @@ func (q *Q19[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T1
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-		var v19s []T19
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
-			if component.ID == q.Components[18] {
-				v19s = component.Data.(*slice[T19]).Data
-				continue
-			}
-
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-		if v16s == nil && !options.Optional[15] {
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
-		if v17s == nil && !options.Optional[16] {
+		if match.columns[16] < 0 && !options.Optional[16] {
 			continue
 		}
-		if v18s == nil && !options.Optional[17] {
+		if match.columns[17] < 0 && !options.Optional[17] {
 			continue
 		}
-		if v19s == nil && !options.Optional[18] {
+		if match.columns[18] < 0 && !options.Optional[18] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]), columnData[T16](compound, match.columns[15]), columnData[T17](compound, match.columns[16]), columnData[T18](compound, match.columns[17]), columnData[T19](compound, match.columns[18]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-		var v19s []T19
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
-			if component.ID == q.Components[18] {
-				v19s = component.Data.(*slice[T19]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
-func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) Each(fn func(ID, *T1, *T2, *T3, *T4, *T5, *T6, *T7, *T8, *T9, *T10, *T11, *T12, *T13, *T14, *T15, *T16, *T17, *T18, *T19, *T20), queryOptions ...Q20Option) {
-	// Skip if there is an error
+
+// EachChunk calls fn once per matching compound with its entity IDs and component columns, index aligned.
+// Optional components missing from a compound are passed as nil. Removed entities are cleaned up
+// before iterating, so every row passed is live. The slices are only valid during the call.
+// Chunks can not skip rows, so EachChunk does nothing when Added, Changed or Since is set,
+// and Mark stamps every row of a chunk.
+func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T15, T16, T17, T18, T19, T20]) EachChunk(fn func([]ID, []T1, []T2, []T3, []T4, []T5, []T6, []T7, []T8, []T9, []T10, []T11, []T12, []T13, []T14, []T15, []T16, []T17, []T18, []T19, []T20), queryOptions ...Q20Option) {
 	if q.Errors != nil {
 		return
 	}
@@ -7164,355 +12756,94 @@ func (q *Q20[ID, T1, T2, T3, T4, T5, T6, T7, T8, T9, T10, T11, T12, T13, T14, T1
 	if len(queryOptions) == 1 {
 		options = queryOptions[0]
 	}
+	if len(options.Added) > 0 || len(options.Changed) > 0 || options.Since != 0 {
+		return
+	}
 	if options.Stop == nil {
 		options.Stop = new(bool)
 	}
-	// Filter and run compounds
-	var compoundCleanup []int
-	q.storage.lock.RLock()
-LOOP:
-	for id, compound := range q.storage.Compounds {
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-		var v19s []T19
-		var v20s []T20
-
-		for _, component := range compound.Components {
-			if options.Hash != nil && component.ID == options.Hash.ID && component.Hash != options.Hash.Hash {
-				continue LOOP
-			}
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
-			if component.ID == q.Components[18] {
-				v19s = component.Data.(*slice[T19]).Data
-				continue
-			}
-			if component.ID == q.Components[19] {
-				v20s = component.Data.(*slice[T20]).Data
-				continue
-			}
-
+	matches := q.storage.rlockClean(&q.cache, q.Components[:], options.Optional[:], true)
+	defer q.storage.runlock()
+	filter := q.storage.filterResolve(options.With, options.Without, options.AnyOf)
+	mark := slices.Contains(options.Mark[:], true)
+	tick := q.storage.tick.Load()
+	for _, match := range matches {
+		compound := q.storage.Compounds[match.compound]
+		if !filter.match(compound.Components) || !hashMatch(compound.Components, options.Hash) {
+			continue
 		}
-		if v1s == nil && !options.Optional[0] {
+		if match.columns[0] < 0 && !options.Optional[0] {
 			continue
 		}
-		if v2s == nil && !options.Optional[1] {
+		if match.columns[1] < 0 && !options.Optional[1] {
 			continue
 		}
-		if v3s == nil && !options.Optional[2] {
+		if match.columns[2] < 0 && !options.Optional[2] {
 			continue
 		}
-		if v4s == nil && !options.Optional[3] {
+		if match.columns[3] < 0 && !options.Optional[3] {
 			continue
 		}
-		if v5s == nil && !options.Optional[4] {
+		if match.columns[4] < 0 && !options.Optional[4] {
 			continue
 		}
-		if v6s == nil && !options.Optional[5] {
+		if match.columns[5] < 0 && !options.Optional[5] {
 			continue
 		}
-		if v7s == nil && !options.Optional[6] {
+		if match.columns[6] < 0 && !options.Optional[6] {
 			continue
 		}
-		if v8s == nil && !options.Optional[7] {
+		if match.columns[7] < 0 && !options.Optional[7] {
 			continue
 		}
-		if v9s == nil && !options.Optional[8] {
+		if match.columns[8] < 0 && !options.Optional[8] {
 			continue
 		}
-		if v10s == nil && !options.Optional[9] {
+		if match.columns[9] < 0 && !options.Optional[9] {
 			continue
 		}
-		if v11s == nil && !options.Optional[10] {
+		if match.columns[10] < 0 && !options.Optional[10] {
 			continue
 		}
-		if v12s == nil && !options.Optional[11] {
+		if match.columns[11] < 0 && !options.Optional[11] {
 			continue
 		}
-		if v13s == nil && !options.Optional[12] {
+		if match.columns[12] < 0 && !options.Optional[12] {
 			continue
 		}
-		if v14s == nil && !options.Optional[13] {
+		if match.columns[13] < 0 && !options.Optional[13] {
 			continue
 		}
-		if v15s == nil && !options.Optional[14] {
+		if match.columns[14] < 0 && !options.Optional[14] {
 			continue
 		}
-		if v16s == nil && !options.Optional[15] {
+		if match.columns[15] < 0 && !options.Optional[15] {
 			continue
 		}
-		if v17s == nil && !options.Optional[16] {
+		if match.columns[16] < 0 && !options.Optional[16] {
 			continue
 		}
-		if v18s == nil && !options.Optional[17] {
+		if match.columns[17] < 0 && !options.Optional[17] {
 			continue
 		}
-		if v19s == nil && !options.Optional[18] {
+		if match.columns[18] < 0 && !options.Optional[18] {
 			continue
 		}
-		if v20s == nil && !options.Optional[19] {
+		if match.columns[19] < 0 && !options.Optional[19] {
 			continue
 		}
 
-		if compound.EntitysRemoved != nil {
-			if !compound.cleanupTime.Swap(true) {
-				compoundCleanup = append(compoundCleanup, id)
-				continue LOOP
-			}
-		loopEach:
-			for idx, id := range compound.Entitys {
-				for _, r := range compound.EntitysRemoved {
-					if r == id {
-						continue loopEach
-					}
-				}
-				fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx), getOptional(v20s, idx))
-				if *options.Stop {
-					break LOOP
-				}
-			}
+		if len(compound.Entitys) == 0 {
 			continue
 		}
-		// Loop
-		for idx, id := range compound.Entitys {
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx), getOptional(v20s, idx))
-			if *options.Stop {
-				break LOOP
+		fn(compound.Entitys, columnData[T1](compound, match.columns[0]), columnData[T2](compound, match.columns[1]), columnData[T3](compound, match.columns[2]), columnData[T4](compound, match.columns[3]), columnData[T5](compound, match.columns[4]), columnData[T6](compound, match.columns[5]), columnData[T7](compound, match.columns[6]), columnData[T8](compound, match.columns[7]), columnData[T9](compound, match.columns[8]), columnData[T10](compound, match.columns[9]), columnData[T11](compound, match.columns[10]), columnData[T12](compound, match.columns[11]), columnData[T13](compound, match.columns[12]), columnData[T14](compound, match.columns[13]), columnData[T15](compound, match.columns[14]), columnData[T16](compound, match.columns[15]), columnData[T17](compound, match.columns[16]), columnData[T18](compound, match.columns[17]), columnData[T19](compound, match.columns[18]), columnData[T20](compound, match.columns[19]))
+		if mark {
+			for idx := range compound.Entitys {
+				markRow(compound, match.columns, options.Mark[:], idx, tick)
 			}
 		}
-	}
-	q.storage.lock.RUnlock()
-	if compoundCleanup == nil {
-		return
-	}
-	// Run cleanups
-	q.storage.lock.Lock()
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		// Abort if we're not going to run the data
 		if *options.Stop {
-			continue
-		}
-		// Select components
-		var v1s []T1
-		var v2s []T2
-		var v3s []T3
-		var v4s []T4
-		var v5s []T5
-		var v6s []T6
-		var v7s []T7
-		var v8s []T8
-		var v9s []T9
-		var v10s []T10
-		var v11s []T11
-		var v12s []T12
-		var v13s []T13
-		var v14s []T14
-		var v15s []T15
-		var v16s []T16
-		var v17s []T17
-		var v18s []T18
-		var v19s []T19
-		var v20s []T20
-
-		for _, component := range compound.Components {
-			if component.ID == q.Components[0] {
-				v1s = component.Data.(*slice[T1]).Data
-				continue
-			}
-			if component.ID == q.Components[1] {
-				v2s = component.Data.(*slice[T2]).Data
-				continue
-			}
-			if component.ID == q.Components[2] {
-				v3s = component.Data.(*slice[T3]).Data
-				continue
-			}
-			if component.ID == q.Components[3] {
-				v4s = component.Data.(*slice[T4]).Data
-				continue
-			}
-			if component.ID == q.Components[4] {
-				v5s = component.Data.(*slice[T5]).Data
-				continue
-			}
-			if component.ID == q.Components[5] {
-				v6s = component.Data.(*slice[T6]).Data
-				continue
-			}
-			if component.ID == q.Components[6] {
-				v7s = component.Data.(*slice[T7]).Data
-				continue
-			}
-			if component.ID == q.Components[7] {
-				v8s = component.Data.(*slice[T8]).Data
-				continue
-			}
-			if component.ID == q.Components[8] {
-				v9s = component.Data.(*slice[T9]).Data
-				continue
-			}
-			if component.ID == q.Components[9] {
-				v10s = component.Data.(*slice[T10]).Data
-				continue
-			}
-			if component.ID == q.Components[10] {
-				v11s = component.Data.(*slice[T11]).Data
-				continue
-			}
-			if component.ID == q.Components[11] {
-				v12s = component.Data.(*slice[T12]).Data
-				continue
-			}
-			if component.ID == q.Components[12] {
-				v13s = component.Data.(*slice[T13]).Data
-				continue
-			}
-			if component.ID == q.Components[13] {
-				v14s = component.Data.(*slice[T14]).Data
-				continue
-			}
-			if component.ID == q.Components[14] {
-				v15s = component.Data.(*slice[T15]).Data
-				continue
-			}
-			if component.ID == q.Components[15] {
-				v16s = component.Data.(*slice[T16]).Data
-				continue
-			}
-			if component.ID == q.Components[16] {
-				v17s = component.Data.(*slice[T17]).Data
-				continue
-			}
-			if component.ID == q.Components[17] {
-				v18s = component.Data.(*slice[T18]).Data
-				continue
-			}
-			if component.ID == q.Components[18] {
-				v19s = component.Data.(*slice[T19]).Data
-				continue
-			}
-			if component.ID == q.Components[19] {
-				v20s = component.Data.(*slice[T20]).Data
-				continue
-			}
-
-		}
-		// Run data
-		idxRemove := make([]int, 0, len(compound.EntitysRemoved))
-	loopRemove:
-		for idx, id := range compound.Entitys {
-			for rIdx, r := range compound.EntitysRemoved {
-				if r == id {
-					idxRemove = append(idxRemove, idx)
-					compound.EntitysRemoved = sliceRemove(compound.EntitysRemoved, rIdx)
-					continue loopRemove
-				}
-			}
-			fn(id, getOptional(v1s, idx), getOptional(v2s, idx), getOptional(v3s, idx), getOptional(v4s, idx), getOptional(v5s, idx), getOptional(v6s, idx), getOptional(v7s, idx), getOptional(v8s, idx), getOptional(v9s, idx), getOptional(v10s, idx), getOptional(v11s, idx), getOptional(v12s, idx), getOptional(v13s, idx), getOptional(v14s, idx), getOptional(v15s, idx), getOptional(v16s, idx), getOptional(v17s, idx), getOptional(v18s, idx), getOptional(v19s, idx), getOptional(v20s, idx))
-			if *options.Stop {
-				continue
-			}
-		}
-		// Cleanup
-		for i := len(idxRemove) - 1; i >= 0; i-- {
-			idx := idxRemove[i]
-			compound.Entitys = sliceRemove(compound.Entitys, idx)
-			for _, component := range compound.Components {
-				component.Data.remove(idx)
-			}
+			return
 		}
-		compound.EntitysRemoved = nil
-	}
-	for _, id := range compoundCleanup {
-		compound := q.storage.Compounds[id]
-		compound.cleanupTime.Store(false)
 	}
-	q.storage.lock.Unlock()
 }
@@ -0,0 +1,28 @@
+package ecs
+
+// Option configures a Storage created by New.
+type Option func(*options)
+
+type options struct {
+	entities         int
+	compactThreshold int
+	unlocked         bool
+}
+
+// WithEntities sizes the storage for about n entities up front, so bulk loads do not rehash.
+func WithEntities(n int) Option {
+	return func(o *options) { o.entities = n }
+}
+
+// WithCompactThreshold sets the compact threshold, see SetCompactThreshold.
+func WithCompactThreshold(rows int) Option {
+	return func(o *options) { o.compactThreshold = rows }
+}
+
+// WithoutLocks skips all locking for storages only ever used from one goroutine at a time.
+// ParEach still runs its callbacks concurrently, which is safe as long as they only read
+// other entities and write their own components. The entity handle allocator stays locked,
+// so Commands.Spawn may still be called from ParEach callbacks.
+func WithoutLocks() Option {
+	return func(o *options) { o.unlocked = true }
+}
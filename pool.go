@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Pool is a set of worker goroutines used by ParEach.
+type Pool struct {
+	workers   int
+	chunkSize int
+	jobs      chan func()
+}
+
+var (
+	defaultPool     *Pool
+	defaultPoolOnce sync.Once
+)
+
+// NewPool starts workers goroutines that run ParEach chunks of up to chunkSize rows.
+// Zero values default to runtime.GOMAXPROCS and 1024.
+func NewPool(workers, chunkSize int) *Pool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if chunkSize <= 0 {
+		chunkSize = 1024
+	}
+	pool := &Pool{workers: workers, chunkSize: chunkSize, jobs: make(chan func(), workers)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range pool.jobs {
+				job()
+			}
+		}()
+	}
+	return pool
+}
+
+// Close stops the workers once queued work is done. The pool must not be used afterwards.
+func (pool *Pool) Close() {
+	close(pool.jobs)
+}
+
+func poolOrDefault(pool *Pool) *Pool {
+	if pool != nil {
+		return pool
+	}
+	defaultPoolOnce.Do(func() { defaultPool = NewPool(0, 0) })
+	return defaultPool
+}
+
+// parChunk is a range of rows in one compound handed to a single worker.
+type parChunk struct {
+	match      queryMatch
+	start, end int
+}
+
+func parChunks(chunks []parChunk, match queryMatch, rows, chunkSize int) []parChunk {
+	for start := 0; start < rows; start += chunkSize {
+		chunks = append(chunks, parChunk{match: match, start: start, end: min(start+chunkSize, rows)})
+	}
+	return chunks
+}
+
+// run calls fn once for every index below n, spread over the pool.
+// The calling goroutine takes part as well, so a busy pool can never stall it.
+func (pool *Pool) run(n int, fn func(int)) {
+	var next atomic.Int64
+	work := func() {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= n {
+				return
+			}
+			fn(i)
+		}
+	}
+	var lock sync.Mutex
+	var finished bool
+	var wg sync.WaitGroup
+	helper := func() {
+		lock.Lock()
+		if finished {
+			lock.Unlock()
+			return
+		}
+		wg.Add(1)
+		lock.Unlock()
+		defer wg.Done()
+		work()
+	}
+HELPERS:
+	for i := 1; i < min(pool.workers, n); i++ {
+		select {
+		case pool.jobs <- helper:
+		default:
+			break HELPERS
+		}
+	}
+	work()
+	lock.Lock()
+	finished = true
+	lock.Unlock()
+	wg.Wait()
+}
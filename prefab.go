@@ -0,0 +1,126 @@
+package ecs
+
+import (
+	"hash/fnv"
+	"reflect"
+)
+
+// PrefabValue is a type-erased component value used by prefabs.
+type PrefabValue struct {
+	t    reflect.Type
+	hash int
+	data Slice // Holds the single value
+}
+
+// Value wraps a component value for NewPrefab, Share and Instantiate.
+func Value[T any](v T) PrefabValue {
+	var hash int
+	if h, ok := any(v).(Hashable); ok {
+		hash = h.Hash()
+	}
+	return PrefabValue{t: typeOf[T](), hash: hash, data: &slice[T]{Data: []T{v}}}
+}
+
+// Prefab is a named template of components. Its values are copied into every instance, while
+// shared values are kept once in the prefab and read with Shared.
+type Prefab struct {
+	Name   string
+	values []PrefabValue
+	shared []PrefabValue
+}
+
+// InstanceOf is the component that links an instance to the prefab it was created from.
+// Instances of different prefabs are kept in different compounds.
+type InstanceOf struct{ Prefab string }
+
+func (instance InstanceOf) Hash() int {
+	h := fnv.New64a()
+	h.Write([]byte(instance.Prefab))
+	return int(h.Sum64())
+}
+
+// NewPrefab returns a prefab whose instances each get a copy of values.
+func NewPrefab(name string, values ...PrefabValue) *Prefab {
+	return &Prefab{Name: name, values: values}
+}
+
+// Share adds values stored once in the prefab instead of in every instance.
+func (prefab *Prefab) Share(values ...PrefabValue) *Prefab {
+	prefab.shared = append(prefab.shared, values...)
+	return prefab
+}
+
+// RegisterPrefab makes a prefab available by its name. Instantiate registers prefabs as well.
+func (storage *Storage[ID]) RegisterPrefab(prefab *Prefab) {
+	storage.wlock()
+	defer storage.unlock()
+	storage.prefabRegister(prefab)
+}
+
+// Prefab returns the prefab registered under name.
+func (storage *Storage[ID]) Prefab(name string) (*Prefab, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	prefab, ok := storage.prefabs[name]
+	return prefab, ok
+}
+
+// Instantiate gives an entity the values of a prefab and an InstanceOf component, replacing its
+// components like SetN. Use Instantiate(storage, storage.Spawn(), prefab) for a new handle.
+// Overrides replace prefab values of the same type, or shared values which the instance then stores itself.
+func Instantiate[ID Int](storage *Storage[ID], id ID, prefab *Prefab, overrides ...PrefabValue) {
+	storage.wlock()
+	defer storage.unlock()
+	storage.prefabRegister(prefab)
+	if storage.allocator.stale(id) {
+		return
+	}
+	values := append([]PrefabValue{Value(InstanceOf{Prefab: prefab.Name})}, prefab.values...)
+	for _, override := range overrides {
+		idx := prefabValueFind(values, override.t)
+		if idx < 0 {
+			values = append(values, override)
+			continue
+		}
+		values[idx] = override
+	}
+	storage.setValues(id, values)
+}
+
+// Shared returns the T component of an entity, falling back to the shared T of its prefab.
+func Shared[T any, ID Int](storage *Storage[ID], id ID) (*T, bool) {
+	if v, ok := Get[T](storage, id); ok {
+		return v, true
+	}
+	instance, ok := Get[InstanceOf](storage, id)
+	if !ok {
+		return nil, false
+	}
+	storage.rlock()
+	defer storage.runlock()
+	prefab, ok := storage.prefabs[instance.Prefab]
+	if !ok {
+		return nil, false
+	}
+	idx := prefabValueFind(prefab.shared, typeOf[T]())
+	if idx < 0 {
+		return nil, false
+	}
+	return &prefab.shared[idx].data.(*slice[T]).Data[0], true
+}
+
+func (storage *Storage[ID]) prefabRegister(prefab *Prefab) {
+	if storage.prefabs == nil {
+		storage.prefabs = map[string]*Prefab{}
+	}
+	storage.prefabs[prefab.Name] = prefab
+}
+
+func prefabValueFind(values []PrefabValue, t reflect.Type) int {
+	for idx, value := range values {
+		if value.t == t {
+			return idx
+		}
+	}
+	return -1
+}
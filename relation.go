@@ -0,0 +1,104 @@
+package ecs
+
+// Pair is the component stored by Relation. Its Hash is the target, so entities related to
+// different targets are kept in different compounds, like any other Hashable component.
+type Pair[R any, ID Int] struct {
+	Target ID
+	Data   R
+}
+
+func (pair Pair[R, ID]) Hash() int { return int(pair.Target) }
+
+// Cascade decides what happens to the entities related to a target when the target is removed.
+type Cascade int
+
+const (
+	CascadeKeep   Cascade = iota // Keep the relation pointing at the removed target
+	CascadeOrphan                // Unset the relation
+	CascadeRemove                // Remove the related entities too
+)
+
+// Relation relates an entity to a target through R, stored as a Pair[R, ID] component.
+// An entity has at most one target per relation, relating it again replaces the target.
+func Relation[R any, ID Int](storage *Storage[ID], id, target ID, v R) {
+	storage.wlock()
+	defer storage.unlock()
+	add(storage, id, Pair[R, ID]{Target: target, Data: v})
+}
+
+// Unrelate removes the R relation of an entity.
+func Unrelate[R any, ID Int](storage *Storage[ID], id ID) {
+	storage.wlock()
+	defer storage.unlock()
+	unset[Pair[R, ID]](storage, id)
+}
+
+// Target returns the target an entity is related to through R.
+func Target[R any, ID Int](storage *Storage[ID], id ID) (ID, bool) {
+	pair, ok := Get[Pair[R, ID]](storage, id)
+	if !ok {
+		return 0, false
+	}
+	return pair.Target, true
+}
+
+// RelationTo returns the Hash query option matching the entities related to target through R,
+// for example Query1[Pair[ChildOf, ID]] with Hash: RelationTo[ChildOf](storage, parent) visits the children of parent.
+func RelationTo[R any, ID Int](storage *Storage[ID], target ID) *ComponentHash {
+	return &ComponentHash{ID: ComponentID[Pair[R, ID]](storage), Hash: int(target)}
+}
+
+// Related returns the entities related to target through R.
+func Related[R any, ID Int](storage *Storage[ID], target ID) []ID {
+	storage.rlock()
+	defer storage.runlock()
+	component, ok := storage.getComponent(typeOf[Pair[R, ID]]())
+	if !ok {
+		return nil
+	}
+	return storage.related(component, target)
+}
+
+// SetCascade sets what removing a target does to the entities related to it through R.
+// The default is CascadeKeep.
+func SetCascade[R any, ID Int](storage *Storage[ID], cascade Cascade) {
+	storage.wlock()
+	defer storage.unlock()
+	if storage.cascades == nil {
+		storage.cascades = map[int]Cascade{}
+	}
+	storage.cascades[componentEnsure[Pair[R, ID]](storage)] = cascade
+}
+
+func (storage *Storage[ID]) related(component int, target ID) []ID {
+	var ids []ID
+	for _, compound := range storage.Compounds {
+		idx, ok := compoundColumn(compound, component)
+		if !ok || compound.Components[idx].Hash != int(target) {
+			continue
+		}
+		for row, id := range compound.Entitys {
+			if !compound.rowRemoved(row) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// cascade applies the cascade policies to the entities related to a removed target.
+func (storage *Storage[ID]) cascade(target ID) {
+	for component, cascade := range storage.cascades {
+		if cascade == CascadeKeep {
+			continue
+		}
+		for _, id := range storage.related(component, target) {
+			switch cascade {
+			case CascadeOrphan:
+				storage.unsetComponent(id, component)
+			case CascadeRemove:
+				storage.remove(id)
+			}
+		}
+	}
+}
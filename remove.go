@@ -1,12 +1,73 @@
 package ecs
 
+// DefaultCompactThreshold is the number of removed rows at which a compound is compacted right away.
+const DefaultCompactThreshold = 1024
+
 func (storage *Storage[ID]) Remove(id ID) {
-	storage.lock.Lock()
-	defer storage.lock.Unlock()
+	storage.wlock()
+	defer storage.unlock()
+	storage.remove(id)
+}
+
+func (storage *Storage[ID]) remove(id ID) {
 	entity, ok := storage.Entitys[id]
 	if !ok {
 		return
 	}
 	delete(storage.Entitys, id)
-	storage.Compounds[entity.Compound].EntitysRemoved = sliceInsertOrdered(storage.Compounds[entity.Compound].EntitysRemoved, id)
+	storage.allocator.release(id)
+	compound := storage.Compounds[entity.Compound]
+	if compound.Removed == nil {
+		compound.Removed = make([]bool, len(compound.Entitys))
+	}
+	compound.Removed[entity.Row] = true
+	compound.RemovedCount++
+	if storage.compactThreshold > 0 && compound.RemovedCount >= max(storage.compactThreshold, len(compound.Entitys)/8) {
+		storage.compoundClean(entity.Compound)
+	}
+	if len(storage.cascades) > 0 {
+		storage.cascade(id)
+	}
+}
+
+// Compact purges the rows of removed entities from every compound. Removed rows are otherwise
+// purged when a query visits their compound or once a compound reaches the compact threshold.
+func (storage *Storage[ID]) Compact() {
+	storage.wlock()
+	defer storage.unlock()
+	for idx := range storage.Compounds {
+		storage.compoundClean(idx)
+	}
+}
+
+// Shrink compacts every compound and releases the spare capacity left behind by mass removals.
+func (storage *Storage[ID]) Shrink() {
+	storage.wlock()
+	defer storage.unlock()
+	entitys := make(map[ID]Entity, len(storage.Entitys)) // Maps never shrink in place
+	for id, entity := range storage.Entitys {
+		entitys[id] = entity
+	}
+	storage.Entitys = entitys
+	for idx, compound := range storage.Compounds {
+		storage.compoundClean(idx)
+		compound.Entitys = sliceShrink(compound.Entitys)
+		for idx := range compound.Components {
+			column := &compound.Components[idx]
+			if column.Data != nil {
+				column.Data.shrink()
+			}
+			column.Added = sliceShrink(column.Added)
+			column.Changed = sliceShrink(column.Changed)
+		}
+	}
+}
+
+// SetCompactThreshold sets how many removed rows a compound may hold before Remove compacts it.
+// Large compounds may hold up to an eighth of their rows, so compacting stays cheap per removal.
+// Zero disables automatic compaction.
+func (storage *Storage[ID]) SetCompactThreshold(rows int) {
+	storage.wlock()
+	defer storage.unlock()
+	storage.compactThreshold = rows
 }
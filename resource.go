@@ -0,0 +1,41 @@
+package ecs
+
+// SetResource stores v as the singleton T of the storage, replacing any previous T.
+// Resources share the component registry, so T may also be used as a component.
+func SetResource[T any, ID Int](storage *Storage[ID], v T) {
+	storage.wlock()
+	defer storage.unlock()
+	component := componentEnsure[T](storage)
+	if resource, ok := storage.Resources[component]; ok {
+		resource.(*slice[T]).Data[0] = v
+		return
+	}
+	if storage.Resources == nil {
+		storage.Resources = map[int]Slice{}
+	}
+	storage.Resources[component] = &slice[T]{Data: []T{v}}
+}
+
+// Resource returns the singleton T of the storage.
+func Resource[T any, ID Int](storage *Storage[ID]) (*T, bool) {
+	storage.rlock()
+	defer storage.runlock()
+	component, ok := storage.getComponent(typeOf[T]())
+	if !ok {
+		return nil, false
+	}
+	resource, ok := storage.Resources[component]
+	if !ok {
+		return nil, false
+	}
+	return &resource.(*slice[T]).Data[0], true
+}
+
+// RemoveResource deletes the singleton T of the storage.
+func RemoveResource[T any, ID Int](storage *Storage[ID]) {
+	storage.wlock()
+	defer storage.unlock()
+	if component, ok := storage.getComponent(typeOf[T]()); ok {
+		delete(storage.Resources, component)
+	}
+}
@@ -0,0 +1,194 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+)
+
+var ErrSnapshotCorrupt = errors.New("ecs: corrupt snapshot")
+
+// Registry maps the component names stored in a snapshot back to Go types.
+type Registry struct {
+	types map[string][]registryType
+}
+
+type registryType struct {
+	t     reflect.Type
+	empty func() Slice
+}
+
+func NewRegistry() *Registry {
+	return &Registry{types: map[string][]registryType{}}
+}
+
+// Register adds T to the registry under its reflect.Type String, the name it is saved with.
+// Registering types that share a name is allowed, but loading a snapshot using that name fails.
+func Register[T any](registry *Registry) {
+	t := typeOf[T]()
+	name := t.String()
+	for _, v := range registry.types[name] {
+		if v.t == t {
+			return
+		}
+	}
+	registry.types[name] = append(registry.types[name], registryType{t: t, empty: func() Slice { return &slice[T]{} }})
+}
+
+func (registry *Registry) lookup(name string) (registryType, error) {
+	switch types := registry.types[name]; len(types) {
+	case 0:
+		return registryType{}, fmt.Errorf("%w: %s", ErrComponentUnknown, name)
+	case 1:
+		return types[0], nil
+	default:
+		return registryType{}, fmt.Errorf("%w: %s matches %d registered types", ErrComponentAmbiguous, name, len(types))
+	}
+}
+
+// snapshot is the saved form of a storage. Hooks, prefabs and cascade policies are not saved.
+type snapshot[ID Int] struct {
+	Tick       uint64
+	Components []string
+	Compounds  []snapshotCompound[ID]
+	Resources  map[int]json.RawMessage
+	Allocator  snapshotAllocator[ID]
+}
+
+type snapshotCompound[ID Int] struct {
+	Entitys    []ID
+	Components []snapshotColumn
+}
+
+type snapshotColumn struct {
+	ID      int
+	Hash    int
+	Data    json.RawMessage
+	Added   []uint64
+	Changed []uint64
+}
+
+type snapshotAllocator[ID Int] struct {
+	Used        bool
+	Generations []ID
+	Alive       []bool
+	Free        []ID
+}
+
+// Save writes every entity, compound, hash and resource of the storage as JSON.
+// Removed rows are purged first, so the snapshot only holds live entities.
+func (storage *Storage[ID]) Save(w io.Writer) error {
+	storage.wlock()
+	defer storage.unlock()
+	snap := snapshot[ID]{Tick: storage.tick.Load(), Resources: map[int]json.RawMessage{}}
+	for _, component := range storage.Components {
+		snap.Components = append(snap.Components, component.Name)
+	}
+	for idx, compound := range storage.Compounds {
+		storage.compoundClean(idx)
+		saved := snapshotCompound[ID]{Entitys: compound.Entitys}
+		for _, column := range compound.Components {
+			data, err := json.Marshal(column.Data)
+			if err != nil {
+				return err
+			}
+			saved.Components = append(saved.Components, snapshotColumn{ID: column.ID, Hash: column.Hash, Data: data, Added: column.Added, Changed: column.Changed})
+		}
+		snap.Compounds = append(snap.Compounds, saved)
+	}
+	for component, resource := range storage.Resources {
+		data, err := json.Marshal(resource)
+		if err != nil {
+			return err
+		}
+		snap.Resources[component] = data
+	}
+	allocator := storage.allocator
+	allocator.lock.Lock()
+	snap.Allocator = snapshotAllocator[ID]{Used: allocator.used.Load(), Generations: slices.Clone(allocator.generations), Alive: slices.Clone(allocator.alive), Free: slices.Clone(allocator.free)}
+	allocator.lock.Unlock()
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// Load reads a storage written by Save, resolving its components through registry.
+// Component IDs and compound order are kept, so they match the saved storage.
+func Load[ID Int](r io.Reader, registry *Registry, opts ...Option) (*Storage[ID], error) {
+	var snap snapshot[ID]
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, err
+	}
+	storage := New[ID](opts...)
+	storage.tick.Store(snap.Tick)
+	types := make([]registryType, len(snap.Components))
+	for idx, name := range snap.Components {
+		t, err := registry.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if storage.componentEnsureType(t.t) != idx {
+			return nil, fmt.Errorf("%w: component %s saved twice", ErrSnapshotCorrupt, name)
+		}
+		types[idx] = t
+	}
+	for idx, saved := range snap.Compounds {
+		components := make([]int, len(saved.Components))
+		hashes := make([]int, len(saved.Components))
+		for i, column := range saved.Components {
+			if column.ID < 0 || column.ID >= len(types) {
+				return nil, fmt.Errorf("%w: compound %d has unknown component %d", ErrSnapshotCorrupt, idx, column.ID)
+			}
+			components[i], hashes[i] = column.ID, column.Hash
+		}
+		if storage.compoundEnsure(components, hashes) != idx {
+			return nil, fmt.Errorf("%w: compound %d saved twice", ErrSnapshotCorrupt, idx)
+		}
+		compound := storage.Compounds[idx]
+		compound.Entitys = saved.Entitys
+		for i, column := range saved.Components {
+			data := types[column.ID].empty()
+			if err := json.Unmarshal(column.Data, data); err != nil {
+				return nil, err
+			}
+			if data.len() != len(saved.Entitys) || len(column.Added) != len(saved.Entitys) || len(column.Changed) != len(saved.Entitys) {
+				return nil, fmt.Errorf("%w: compound %d column %d has the wrong length", ErrSnapshotCorrupt, idx, i)
+			}
+			compound.Components[i].Data, compound.Components[i].Added, compound.Components[i].Changed = data, column.Added, column.Changed
+		}
+		for row, id := range saved.Entitys {
+			if _, ok := storage.Entitys[id]; ok {
+				return nil, fmt.Errorf("%w: entity %d saved twice", ErrSnapshotCorrupt, id)
+			}
+			storage.Entitys[id] = Entity{Compound: idx, Row: row}
+		}
+	}
+	for component, saved := range snap.Resources {
+		if component < 0 || component >= len(types) {
+			return nil, fmt.Errorf("%w: unknown resource component %d", ErrSnapshotCorrupt, component)
+		}
+		data := types[component].empty()
+		if err := json.Unmarshal(saved, data); err != nil {
+			return nil, err
+		}
+		if storage.Resources == nil {
+			storage.Resources = map[int]Slice{}
+		}
+		storage.Resources[component] = data
+	}
+	if len(snap.Allocator.Generations) != len(snap.Allocator.Alive) {
+		return nil, fmt.Errorf("%w: allocator generations and alive differ in length", ErrSnapshotCorrupt)
+	}
+	freed := make([]bool, len(snap.Allocator.Alive))
+	for _, index := range snap.Allocator.Free {
+		if index < 0 || index >= ID(len(freed)) || snap.Allocator.Alive[index] || freed[index] {
+			return nil, fmt.Errorf("%w: allocator frees slot %d", ErrSnapshotCorrupt, index)
+		}
+		freed[index] = true
+	}
+	allocator := storage.allocator
+	allocator.used.Store(snap.Allocator.Used)
+	allocator.generations, allocator.alive, allocator.free = snap.Allocator.Generations, snap.Allocator.Alive, snap.Allocator.Free
+	return storage, nil
+}
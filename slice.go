@@ -1,19 +1,42 @@
 package ecs
 
 type Slice interface {
-	remove(...int)
+	remove(int)
+	empty() Slice
+	appendFrom(Slice, int)
+	get(int) any
+	shrink()
+	len() int
 }
 
 type slice[V any] struct {
 	Data []V
 }
 
-func (s *slice[V]) remove(idxs ...int) {
-	for _, idx := range idxs {
-		s.Data = sliceRemove(s.Data, idx)
-	}
+func (s *slice[V]) remove(idx int) {
+	s.Data = sliceRemove(s.Data, idx)
 }
 
 func (s *slice[V]) append(vs ...V) {
 	s.Data = append(s.Data, vs...)
 }
+
+func (s *slice[V]) empty() Slice {
+	return &slice[V]{}
+}
+
+func (s *slice[V]) appendFrom(src Slice, idx int) {
+	s.Data = append(s.Data, src.(*slice[V]).Data[idx])
+}
+
+func (s *slice[V]) get(idx int) any {
+	return s.Data[idx]
+}
+
+func (s *slice[V]) shrink() {
+	s.Data = sliceShrink(s.Data)
+}
+
+func (s *slice[V]) len() int {
+	return len(s.Data)
+}
@@ -1,6 +1,8 @@
 package ecs
 
 import (
+	"errors"
+	"reflect"
 	"sync"
 	"sync/atomic"
 )
@@ -12,49 +14,113 @@ type Int interface {
 }
 
 type Storage[ID Int] struct {
-	lock       sync.RWMutex
-	Entitys    map[ID]Entity
-	Components []Component
-	Compounds  []*Compound[ID]
+	lock           sync.RWMutex
+	Entitys        map[ID]Entity
+	Components     []Component
+	componentTypes map[reflect.Type]int
+	Compounds      []*Compound[ID]
+	compoundIndex  map[uint64][]int // Compounds by compoundKey
+	Resources      map[int]Slice    // Singleton values by component
+	allocator      *allocator[ID]
+	tick           atomic.Uint64
+	hooks          map[int]*componentHooks[ID]
+	events         []func()        // Hooks to run once the write lock is released
+	cascades       map[int]Cascade // Removal policy by relation Pair component
+	prefabs        map[string]*Prefab
+
+	compactThreshold int
+	unlocked         bool // Created WithoutLocks, the storage lock is never taken
 }
 
 type Entity struct {
 	Compound int
+	Row      int
 }
 
 type Component struct {
 	Name string
+	Type reflect.Type `json:"-"` // Identity of the component, Name alone is not unique across packages
+
+	hashable bool // Type implements Hashable
 }
 
+var (
+	ErrComponentUnknown   = errors.New("ecs: unknown component")
+	ErrComponentAmbiguous = errors.New("ecs: ambiguous component name")
+)
+
 type Compound[ID Int] struct {
-	Components     []CompoundComponent
-	Entitys        []ID
-	EntitysRemoved []ID
-	cleanupTime    atomic.Bool
+	Components   []CompoundComponent
+	Entitys      []ID
+	Removed      []bool // Tombstone per row, nil while no row is removed
+	RemovedCount int
 }
 
 type CompoundComponent struct {
-	ID   int
-	Hash int
-	Data Slice
+	ID      int
+	Hash    int
+	Data    Slice
+	Added   []uint64 // Tick each row got the component
+	Changed []uint64 // Tick each row was last written
 }
 
 type Hashable interface{ Hash() int }
 
 type ComponentHash struct{ ID, Hash int }
 
-func New[ID Int]() *Storage[ID] {
-	return &Storage[ID]{Entitys: map[ID]Entity{}}
+func New[ID Int](opts ...Option) *Storage[ID] {
+	o := options{compactThreshold: DefaultCompactThreshold}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	storage := &Storage[ID]{Entitys: make(map[ID]Entity, o.entities), allocator: newAllocator[ID](), compactThreshold: o.compactThreshold, unlocked: o.unlocked}
+	storage.tick.Store(1) // Keep Since 0 meaning every change
+	return storage
+}
+
+// ComponentID returns the component ID of T, registering T if it was never stored.
+func ComponentID[T any, ID Int](storage *Storage[ID]) int {
+	storage.rlock()
+	id, ok := storage.getComponent(typeOf[T]())
+	storage.runlock()
+	if ok {
+		return id
+	}
+	storage.wlock()
+	defer storage.unlock()
+	return componentEnsure[T](storage)
 }
 
 func ComponentLookup[T any, ID Int](storage *Storage[ID]) (int, bool) {
-	storage.lock.RLock()
-	defer storage.lock.RUnlock()
-	name := typeName[T]()
-	for id, cmp := range storage.Components {
-		if cmp.Name == name {
-			return id, true
-		}
+	storage.rlock()
+	defer storage.runlock()
+	return storage.getComponent(typeOf[T]())
+}
+
+// ComponentLookupName returns the component stored under name, the reflect.Type String of its Go
+// type. It fails with ErrComponentUnknown or, when types from different packages share the
+// name, ErrComponentAmbiguous.
+func ComponentLookupName[ID Int](storage *Storage[ID], name string) (int, error) {
+	storage.rlock()
+	defer storage.runlock()
+	return storage.componentByName(name)
+}
+
+// wlock write locks the storage, release it with unlock so queued hooks run.
+func (storage *Storage[ID]) wlock() {
+	if !storage.unlocked {
+		storage.lock.Lock()
+	}
+}
+
+func (storage *Storage[ID]) rlock() {
+	if !storage.unlocked {
+		storage.lock.RLock()
+	}
+}
+
+func (storage *Storage[ID]) runlock() {
+	if !storage.unlocked {
+		storage.lock.RUnlock()
 	}
-	return 0, false
 }
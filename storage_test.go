@@ -1,10 +1,17 @@
 package ecs
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"math/rand"
 )
@@ -33,6 +40,923 @@ func TestBasic(t *testing.T) {
 	t.Log(string(js))
 }
 
+func TestGet(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 10; i++ {
+		Set2(storage, i, Position{int(i), 0}, Walking{int(i)})
+	}
+	Set1(storage, 10, Momentum{1, 1})
+	storage.Remove(2)
+	storage.Remove(5)
+	Query1[Position](storage).Each(func(id uint32, p *Position) {})
+	for i := uint32(0); i < 10; i++ {
+		p, w, ok := Get2[Position, Walking](storage, i)
+		if i == 2 || i == 5 {
+			if ok {
+				t.Fatalf("removed entity %d still found", i)
+			}
+			continue
+		}
+		if !ok || p.X != int(i) || w.Speed != int(i) {
+			t.Fatalf("entity %d got %v %v %v", i, p, w, ok)
+		}
+	}
+	if !Has[Momentum](storage, 10) || Has[Position](storage, 10) {
+		t.Fatal("Has mismatch for entity 10")
+	}
+	if p, ok := Get[Position](storage, 9); ok {
+		p.X = 90
+	}
+	if p, _ := Get[Position](storage, 9); p.X != 90 {
+		t.Fatal("Get pointer does not point into storage")
+	}
+}
+
+func TestAddUnset(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 1, Position{1, 1})
+	Set1(storage, 2, Position{2, 2})
+	Set1(storage, 3, Position{3, 3})
+	Add(storage, 1, Walking{10})
+	Add(storage, 1, Walking{20})
+	Add(storage, 4, Momentum{4, 4})
+	if p, w, ok := Get2[Position, Walking](storage, 1); !ok || p.X != 1 || w.Speed != 20 {
+		t.Fatalf("entity 1 got %v %v %v", p, w, ok)
+	}
+	if p, ok := Get[Position](storage, 3); !ok || p.X != 3 {
+		t.Fatalf("entity 3 got %v %v", p, ok)
+	}
+	var walking int
+	Query1[Walking](storage).Each(func(id uint32, w *Walking) { walking++ })
+	if walking != 1 {
+		t.Fatalf("expected 1 walking entity, got %d", walking)
+	}
+	Unset[Position](storage, 1)
+	if Has[Position](storage, 1) || !Has[Walking](storage, 1) {
+		t.Fatal("Unset did not detach Position from entity 1")
+	}
+	Unset[Walking](storage, 1)
+	if Has[Walking](storage, 1) {
+		t.Fatal("Unset did not detach Walking from entity 1")
+	}
+	var positions int
+	Query1[Position](storage).Each(func(id uint32, p *Position) {
+		if p.X != int(id) {
+			t.Fatalf("entity %d has position %v", id, p)
+		}
+		positions++
+	})
+	if positions != 2 {
+		t.Fatalf("expected 2 positions, got %d", positions)
+	}
+	if m, ok := Get[Momentum](storage, 4); !ok || m.HS != 4 {
+		t.Fatalf("entity 4 got %v %v", m, ok)
+	}
+}
+
+func TestSetExistingSameComponents(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 1, Position{1, 1})
+	Set1(storage, 2, Position{2, 2})
+	Set1(storage, 1, Position{10, 10})
+	var count int
+	Query1[Position](storage).Each(func(id uint32, p *Position) {
+		if id == 1 && p.X != 10 {
+			t.Fatalf("entity 1 was not updated in place, got %v", p)
+		}
+		count++
+	})
+	if count != 2 {
+		t.Fatalf("expected 2 positions, got %d", count)
+	}
+}
+
+func TestSetExistingDifferentComponents(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 1, Position{1, 1})
+	Set1(storage, 2, Position{2, 2})
+	Set1(storage, 3, Position{3, 3})
+	Set2(storage, 1, Position{10, 10}, Walking{1})
+	Set2(storage, 2, Walking{2}, Position{20, 20})
+	var positions, walking int
+	Query1[Position](storage).Each(func(id uint32, p *Position) { positions++ })
+	Query1[Walking](storage).Each(func(id uint32, w *Walking) { walking++ })
+	if positions != 3 || walking != 2 {
+		t.Fatalf("expected 3 positions and 2 walking, got %d and %d", positions, walking)
+	}
+	if p, ok := Get[Position](storage, 3); !ok || p.X != 3 {
+		t.Fatalf("entity 3 got %v %v", p, ok)
+	}
+	if p, w, ok := Get2[Position, Walking](storage, 2); !ok || p.X != 20 || w.Speed != 2 {
+		t.Fatalf("entity 2 got %v %v %v", p, w, ok)
+	}
+	Set1(storage, 1, Momentum{1, 1})
+	if Has[Position](storage, 1) || !Has[Momentum](storage, 1) {
+		t.Fatal("entity 1 kept components from its previous Set")
+	}
+}
+
+func TestSpawnGenerations(t *testing.T) {
+	storage := New[uint32]()
+	a := storage.Spawn()
+	Add(storage, a, Position{1, 1})
+	storage.Remove(a)
+	b := storage.Spawn()
+	if a == b {
+		t.Fatal("recycled handle has the same generation")
+	}
+	if a&0xffffff != b&0xffffff {
+		t.Fatal("expected the freed index to be recycled")
+	}
+	if storage.Alive(a) || !storage.Alive(b) {
+		t.Fatal("Alive does not reflect handle generations")
+	}
+	Set1(storage, a, Position{2, 2})
+	if Has[Position](storage, a) {
+		t.Fatal("Set accepted a stale handle")
+	}
+	Set1(storage, b, Position{3, 3})
+	storage.Remove(a)
+	if p, ok := Get[Position](storage, b); !ok || p.X != 3 {
+		t.Fatalf("live handle got %v %v after removing a stale handle", p, ok)
+	}
+	var count int
+	Query1[Position](storage).Each(func(id uint32, p *Position) {
+		if id != b {
+			t.Fatalf("unexpected entity %d", id)
+		}
+		count++
+	})
+	if count != 1 {
+		t.Fatalf("expected 1 position, got %d", count)
+	}
+}
+
+func TestSpawnSignedWraparound(t *testing.T) {
+	storage := New[int16]()
+	for i := 0; i < 20; i++ {
+		id := storage.Spawn()
+		if id < 0 || !storage.Alive(id) {
+			t.Fatalf("spawn %d returned dead handle %d", i, id)
+		}
+		storage.Remove(id)
+	}
+}
+
+func TestSpawnExistingID(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 0, Position{7, 7})
+	id := storage.Spawn()
+	if id == 0 {
+		t.Fatal("Spawn returned the ID of an existing entity")
+	}
+	if p, ok := Get[Position](storage, 0); !ok || p.X != 7 {
+		t.Fatal("Spawn dropped an existing entity")
+	}
+	cmd := storage.Commands()
+	spawned := cmd.Spawn()
+	Set1(storage, spawned, Position{8, 8})
+	cmd.Apply()
+	var ids []uint32
+	Query1[Position](storage).Each(func(id uint32, p *Position) { ids = append(ids, id) })
+	if Has[Position](storage, spawned) || slices.Contains(ids, spawned) {
+		t.Fatalf("spawned entity %d kept a ghost row, got %v", spawned, ids)
+	}
+}
+
+func TestEachCmd(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 10; i++ {
+		Set1(storage, i, Position{int(i), 0})
+	}
+	Query1[Position](storage).EachCmd(func(cmd *Commands[uint32], id uint32, p *Position) {
+		switch {
+		case id%2 == 0:
+			cmd.Remove(id)
+		case id == 1:
+			CommandAdd(cmd, id, Walking{1})
+		case id == 3:
+			CommandSet1(cmd, id, Momentum{3, 3})
+		case id == 5:
+			CommandUnset[Position](cmd, id)
+		}
+	})
+	var positions int
+	Query1[Position](storage).Each(func(id uint32, p *Position) { positions++ })
+	if positions != 3 {
+		t.Fatalf("expected 3 positions, got %d", positions)
+	}
+	if !Has[Walking](storage, 1) || !Has[Momentum](storage, 3) || Has[Position](storage, 5) {
+		t.Fatal("recorded commands were not applied")
+	}
+}
+
+func TestCommandsSpawn(t *testing.T) {
+	storage := New[uint32]()
+	parent := storage.Spawn()
+	Add(storage, parent, Position{1, 1})
+	var spawned uint32
+	Query1[Position](storage).EachCmd(func(cmd *Commands[uint32], id uint32, p *Position) {
+		spawned = cmd.Spawn()
+		CommandAdd(cmd, spawned, Momentum{p.X, p.Y})
+	})
+	if !storage.Alive(spawned) || spawned == parent {
+		t.Fatal("spawned entity is not alive")
+	}
+	if m, ok := Get[Momentum](storage, spawned); !ok || m.HS != 1 {
+		t.Fatalf("spawned entity got %v %v", m, ok)
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	type Frozen struct{}
+	type Burning struct{}
+	storage := New[uint32]()
+	Set1(storage, 1, Position{})
+	Set2(storage, 2, Position{}, Frozen{})
+	Set2(storage, 3, Position{}, Walking{})
+	Set3(storage, 4, Position{}, Walking{}, Frozen{})
+	count := func(options Q1Option) (ids []uint32) {
+		Query1[Position](storage).Each(func(id uint32, p *Position) { ids = append(ids, id) }, options)
+		slices.Sort(ids)
+		return ids
+	}
+	for _, test := range []struct {
+		options Q1Option
+		ids     []uint32
+	}{
+		{Q1Option{Without: []ComponentType{TypeOf[Frozen]()}}, []uint32{1, 3}},
+		{Q1Option{With: []ComponentType{TypeOf[Walking]()}}, []uint32{3, 4}},
+		{Q1Option{With: []ComponentType{TypeOf[Walking]()}, Without: []ComponentType{TypeOf[Frozen]()}}, []uint32{3}},
+		{Q1Option{AnyOf: []ComponentType{TypeOf[Walking](), TypeOf[Frozen]()}}, []uint32{2, 3, 4}},
+		{Q1Option{Without: []ComponentType{TypeOf[Burning]()}}, []uint32{1, 2, 3, 4}},
+		{Q1Option{With: []ComponentType{TypeOf[Burning]()}}, nil},
+	} {
+		if ids := count(test.options); !slices.Equal(ids, test.ids) {
+			t.Errorf("%+v: expected %v, got %v", test.options, test.ids, ids)
+		}
+	}
+}
+
+func TestQueryCacheNewCompounds(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 1, Position{1, 1})
+	Set1(storage, 4, Walking{4})
+	q := Query2[Position, Walking](storage)
+	count := func(options ...Q2Option) (n int) {
+		q.Each(func(id uint32, p *Position, w *Walking) { n++ }, options...)
+		return n
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("expected no matches, got %d", n)
+	}
+	Set2(storage, 2, Walking{2}, Position{2, 2})
+	Set3(storage, 3, Position{3, 3}, Momentum{}, Walking{3})
+	if n := count(); n != 2 {
+		t.Fatalf("expected 2 matches after new compounds, got %d", n)
+	}
+	if n := count(Q2Option{Optional: [2]bool{false, true}}); n != 3 {
+		t.Fatalf("expected 3 matches with optional Walking, got %d", n)
+	}
+}
+
+func TestQueryAll(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 10; i++ {
+		Set2(storage, i, Position{int(i), 0}, Walking{1})
+	}
+	for id, row := range Query2[Position, Walking](storage).All() {
+		row.V1.X += row.V2.Speed
+		if id == 5 {
+			break
+		}
+	}
+	// Break while the compound is pending cleanup
+	storage.Remove(0)
+	var visited int
+	for range Query1[Position](storage).All() {
+		visited++
+		if visited == 3 {
+			break
+		}
+	}
+	if visited != 3 {
+		t.Fatalf("expected to visit 3 entities, got %d", visited)
+	}
+	var sum int
+	for _, row := range Query1[Position](storage).All() {
+		sum += row.V1.X
+	}
+	if sum != 50 {
+		t.Fatalf("expected position sum 50, got %d", sum)
+	}
+	// Deadlocks if an early break left the storage locked
+	Set1(storage, 20, Position{})
+}
+
+func TestParEach(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 10_000; i++ {
+		Set1(storage, i, Position{int(i), 0})
+		if i%3 == 0 {
+			Add(storage, i, Walking{1})
+		}
+	}
+	storage.Remove(1)
+	pool := NewPool(4, 100)
+	defer pool.Close()
+	var visited atomic.Int64
+	Query1[Position](storage).ParEach(pool, func(id uint32, p *Position) {
+		p.Y++
+		visited.Add(1)
+	})
+	if visited.Load() != 9_999 {
+		t.Fatalf("expected 9999 visits, got %d", visited.Load())
+	}
+	Query1[Position](storage).Each(func(id uint32, p *Position) {
+		if p.Y != 1 {
+			t.Fatalf("entity %d visited %d times", id, p.Y)
+		}
+	})
+}
+
+func TestEachChunk(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 100; i++ {
+		Set2(storage, i, Position{int(i), 0}, Momentum{1, 1})
+		if i%2 == 0 {
+			Add(storage, i, Walking{})
+		}
+	}
+	storage.Remove(10)
+	storage.Remove(11)
+	var rows, chunks int
+	Query2[Position, Momentum](storage).EachChunk(func(ids []uint32, ps []Position, ms []Momentum) {
+		chunks++
+		for i := range ids {
+			if ps[i].X != int(ids[i]) || ids[i] == 10 || ids[i] == 11 {
+				t.Fatalf("row %d has entity %d with position %v", i, ids[i], ps[i])
+			}
+			ps[i].Y += ms[i].VS
+			rows++
+		}
+	})
+	if chunks != 2 || rows != 98 {
+		t.Fatalf("expected 98 rows in 2 chunks, got %d in %d", rows, chunks)
+	}
+	if p, ok := Get[Position](storage, 12); !ok || p.Y != 1 {
+		t.Fatalf("entity 12 got %v %v", p, ok)
+	}
+	Query1[Position](storage).EachChunk(func(ids []uint32, ps []Position) {
+		t.Fatal("EachChunk ran with a Changed filter")
+	}, Q1Option{Changed: []ComponentType{TypeOf[Position]()}})
+}
+
+func TestChangeDetection(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 6; i++ {
+		Set1(storage, i, Position{})
+	}
+	Set1(storage, 6, Walking{})
+	q := Query1[Position](storage)
+	visit := func(options Q1Option) (ids []uint32) {
+		q.Each(func(id uint32, p *Position) { ids = append(ids, id) }, options)
+		slices.Sort(ids)
+		return ids
+	}
+	added := []ComponentType{TypeOf[Position]()}
+	changed := []ComponentType{TypeOf[Position]()}
+	if ids := visit(Q1Option{Added: added}); len(ids) != 6 {
+		t.Fatalf("expected every entity to be added, got %v", ids)
+	}
+	since := storage.Tick()
+	Set1(storage, 1, Position{1, 1})
+	Add(storage, 3, Walking{})
+	Set1(storage, 6, Position{})
+	// Moving an entity keeps the Added tick of the components it already had
+	Set2(storage, 5, Position{}, Walking{})
+	Clone(storage, 3, 2)
+	Query1[Walking](storage).Each(func(id uint32, w *Walking) {}, Q1Option{Mark: [1]bool{true}})
+	Query1[Position](storage).Each(func(id uint32, p *Position) {}, Q1Option{Mark: [1]bool{true}, With: []ComponentType{TypeOf[Walking]()}})
+	MarkChanged[Position](storage, 4)
+	if ids := visit(Q1Option{Added: added, Since: since}); !slices.Equal(ids, []uint32{6}) {
+		t.Fatalf("expected only entity 6 to be added, got %v", ids)
+	}
+	if ids := visit(Q1Option{Changed: changed, Since: since}); !slices.Equal(ids, []uint32{1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("expected entities 1 to 6 to be changed, got %v", ids)
+	}
+	since = storage.Tick()
+	if ids := visit(Q1Option{Changed: changed, Since: since}); len(ids) != 0 {
+		t.Fatalf("expected no changes in a new tick, got %v", ids)
+	}
+	Set1(storage, 2, Position{})
+	if ids := visit(Q1Option{Changed: changed, Since: since}); !slices.Equal(ids, []uint32{2}) {
+		t.Fatalf("expected entity 2 to be changed, got %v", ids)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	storage := New[uint32]()
+	var events []string
+	OnAdd(storage, func(id uint32, p Position) {
+		Has[Position](storage, id) // Deadlocks if hooks ran under the lock
+		events = append(events, fmt.Sprint("add ", id, " ", p.X))
+	})
+	OnSet(storage, func(id uint32, p Position) { events = append(events, fmt.Sprint("set ", id, " ", p.X)) })
+	OnRemove(storage, func(id uint32, p Position) { events = append(events, fmt.Sprint("remove ", id, " ", p.X)) })
+	Set1(storage, 1, Position{1, 0})
+	Set1(storage, 2, Position{2, 0})
+	Set1(storage, 1, Position{10, 0})
+	Set2(storage, 1, Position{11, 0}, Walking{})
+	Add(storage, 2, Position{20, 0})
+	Set1(storage, 1, Walking{})
+	Add(storage, 3, Position{3, 0})
+	Unset[Position](storage, 3)
+	storage.Remove(2)
+	if len(events) != 8 {
+		t.Fatalf("expected removal to wait for cleanup, got %v", events)
+	}
+	Query1[Position](storage).Each(func(id uint32, p *Position) {})
+	expected := []string{
+		"add 1 1", "add 2 2", "set 1 10", "set 1 11", "set 2 20", "remove 1 11",
+		"add 3 3", "remove 3 3", "remove 2 20",
+	}
+	if !slices.Equal(events, expected) {
+		t.Fatalf("expected %v, got %v", expected, events)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	storage := New[uint32]()
+	storage.SetCompactThreshold(0)
+	var removed int
+	OnRemove(storage, func(id uint32, w Walking) { removed++ })
+	for i := uint32(0); i < 10; i++ {
+		Set1(storage, i, Position{int(i), 0})
+		Set1(storage, i+100, Walking{})
+	}
+	storage.Remove(3)
+	storage.Remove(104)
+	// Re-setting a removed ID must not be hidden by its old row
+	Set1(storage, 3, Position{30, 0})
+	var ids []uint32
+	Query1[Position](storage).Each(func(id uint32, p *Position) { ids = append(ids, id) })
+	if len(ids) != 10 || !slices.Contains(ids, 3) {
+		t.Fatalf("expected 10 positions including 3, got %v", ids)
+	}
+	if removed != 0 || storage.Compounds[1].RemovedCount != 1 {
+		t.Fatal("expected the unqueried compound to keep its removed row")
+	}
+	storage.Compact()
+	if removed != 1 || len(storage.Compounds[1].Entitys) != 9 || storage.Compounds[1].Removed != nil {
+		t.Fatal("Compact did not purge the removed row")
+	}
+	storage.SetCompactThreshold(2)
+	storage.Remove(105)
+	storage.Remove(106)
+	if removed != 3 || len(storage.Compounds[1].Entitys) != 7 {
+		t.Fatal("reaching the threshold did not compact the compound")
+	}
+}
+
+func TestEachCleanupUnderReadLock(t *testing.T) {
+	storage := New[uint32]()
+	for i := uint32(0); i < 5; i++ {
+		Set1(storage, i, Position{int(i), 0})
+	}
+	storage.Remove(1)
+	var ids []uint32
+	Query1[Position](storage).Each(func(id uint32, p *Position) {
+		ids = append(ids, id)
+		// Other readers must be able to run alongside the callback
+		done := make(chan bool)
+		go func() { done <- Has[Position](storage, id) }()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("callback ran under the write lock")
+		}
+	})
+	var again []uint32
+	Query1[Position](storage).Each(func(id uint32, p *Position) { again = append(again, id) })
+	if len(ids) != 4 || !slices.Equal(ids, again) {
+		t.Fatalf("expected the same order with and without cleanup, got %v and %v", ids, again)
+	}
+}
+
+func TestComponentTypeIdentity(t *testing.T) {
+	type Position struct{ X, Y int } // Same reflect String as the package level Position
+	storage := New[uint32]()
+	Set1(storage, 1, Position{1, 1})
+	Set1(storage, 2, packagePosition(2))
+	if _, ok := Get[Position](storage, 2); ok {
+		t.Fatal("local Position resolved to the package Position")
+	}
+	if p, ok := Get[Position](storage, 1); !ok || p.X != 1 {
+		t.Fatal("local Position not stored")
+	}
+	if len(storage.Components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(storage.Components))
+	}
+	if _, err := ComponentLookupName(storage, "ecs.Position"); !errors.Is(err, ErrComponentAmbiguous) {
+		t.Fatalf("expected an ambiguous name error, got %v", err)
+	}
+	if _, err := ComponentLookupName(storage, "ecs.Missing"); !errors.Is(err, ErrComponentUnknown) {
+		t.Fatalf("expected an unknown name error, got %v", err)
+	}
+}
+
+func packagePosition(x int) Position { return Position{x, x} }
+
+type Team struct{ ID int }
+
+func (team Team) Hash() int { return team.ID }
+
+func TestSetCompoundLookup(t *testing.T) {
+	storage := New[uint32]()
+	Set2(storage, 1, Position{}, Team{1})
+	Set2(storage, 2, Team{1}, Position{})
+	Set2(storage, 3, Position{}, Team{2})
+	if storage.Entitys[1].Compound != storage.Entitys[2].Compound {
+		t.Fatal("component order split the compound")
+	}
+	if storage.Entitys[1].Compound == storage.Entitys[3].Compound {
+		t.Fatal("different hashes shared a compound")
+	}
+	allocs := testing.AllocsPerRun(100, func() { Set2(storage, 1, Position{1, 1}, Team{1}) })
+	if allocs != 0 {
+		t.Fatalf("expected overwriting Set2 not to allocate, got %v allocs", allocs)
+	}
+	if id := ComponentID[Team](storage); id != storage.Compounds[storage.Entitys[3].Compound].Components[1].ID {
+		t.Fatalf("unexpected component id %d for Team", id)
+	}
+}
+
+func TestSetBatch(t *testing.T) {
+	storage := New[uint32]()
+	Set1(storage, 2, Walking{})
+	var added int
+	OnAdd(storage, func(id uint32, p Position) { added++ })
+	SetBatch2(storage, []uint32{1, 2, 3}, []Position{{1, 0}, {2, 0}, {3, 0}}, []Momentum{{}, {}, {}})
+	if added != 3 {
+		t.Fatalf("expected 3 OnAdd calls, got %d", added)
+	}
+	for id := uint32(1); id <= 3; id++ {
+		p, m, ok := Get2[Position, Momentum](storage, id)
+		if !ok || m == nil || p.X != int(id) {
+			t.Fatalf("entity %d not set by the batch", id)
+		}
+	}
+	if _, ok := Get[Walking](storage, 2); ok {
+		t.Fatal("an existing entity kept components the batch did not set")
+	}
+	teams := SpawnBatch2(storage, []Position{{}, {}}, []Team{{1}, {2}})
+	if len(teams) != 2 || !storage.Alive(teams[0]) || storage.Entitys[teams[0]].Compound == storage.Entitys[teams[1]].Compound {
+		t.Fatal("SpawnBatch2 did not split hashed components into their compounds")
+	}
+	storage = New[uint32]()
+	Set1(storage, 0, Position{7, 7})
+	spawned := SpawnBatch1(storage, []Position{{1, 1}})
+	if p, ok := Get[Position](storage, 0); spawned[0] == 0 || !ok || p.X != 7 {
+		t.Fatal("SpawnBatch1 reused the ID of an existing entity")
+	}
+}
+
+func TestReserveShrink(t *testing.T) {
+	storage := New[uint32](WithEntities(100), WithCompactThreshold(0))
+	Reserve2[Position, Momentum](storage, 100)
+	compound := storage.Compounds[0]
+	if cap(compound.Entitys) < 100 || cap(compound.Components[0].Data.(*slice[Position]).Data) < 100 {
+		t.Fatal("Reserve2 did not grow the compound")
+	}
+	reserved := cap(compound.Entitys)
+	for i := uint32(0); i < 100; i++ {
+		Set2(storage, i, Position{int(i), 0}, Momentum{})
+	}
+	if len(storage.Compounds) != 1 || cap(compound.Entitys) != reserved {
+		t.Fatal("Set2 did not fill the reserved compound")
+	}
+	for i := uint32(0); i < 90; i++ {
+		storage.Remove(i)
+	}
+	storage.Shrink()
+	if len(compound.Entitys) != 10 || cap(compound.Entitys) != 10 || cap(compound.Components[1].Data.(*slice[Momentum]).Data) != 10 {
+		t.Fatal("Shrink did not release the removed rows")
+	}
+	if p, ok := Get[Position](storage, 95); !ok || p.X != 95 {
+		t.Fatal("entity lost by Shrink")
+	}
+}
+
+func TestWithoutLocks(t *testing.T) {
+	storage := New[uint32](WithoutLocks())
+	var added int
+	OnAdd(storage, func(id uint32, p Position) { added++ })
+	id := storage.Spawn()
+	Set2(storage, id, Position{1, 2}, Walking{})
+	storage.Remove(storage.Spawn())
+	var n int
+	Query1[Position](storage).Each(func(id uint32, p *Position) { n++ })
+	if n != 1 || added != 1 {
+		t.Fatalf("expected 1 position and 1 OnAdd, got %d and %d", n, added)
+	}
+	SpawnBatch1(storage, make([]Position, 999))
+	pool := NewPool(4, 100)
+	defer pool.Close()
+	cmd := storage.Commands()
+	Query1[Position](storage).ParEach(pool, func(id uint32, p *Position) { cmd.Spawn() })
+	cmd.Apply()
+	if len(storage.Entitys) != 2_000 {
+		t.Fatalf("expected 2000 entities after spawning from ParEach, got %d", len(storage.Entitys))
+	}
+}
+
+func TestResources(t *testing.T) {
+	type Clock struct{ Frame int }
+	storage := New[uint32]()
+	if _, ok := Resource[Clock](storage); ok {
+		t.Fatal("resource exists before it was set")
+	}
+	SetResource(storage, Clock{1})
+	clock, ok := Resource[Clock](storage)
+	if !ok || clock.Frame != 1 {
+		t.Fatal("resource not stored")
+	}
+	clock.Frame++
+	SetResource(storage, Position{3, 4})
+	if clock, _ := Resource[Clock](storage); clock.Frame != 2 {
+		t.Fatal("resource pointer does not write through")
+	}
+	js, err := json.Marshal(storage)
+	if err != nil || !strings.Contains(string(js), `"Resources"`) {
+		t.Fatalf("resources not serialized: %v", err)
+	}
+	RemoveResource[Clock](storage)
+	if _, ok := Resource[Clock](storage); ok {
+		t.Fatal("resource not removed")
+	}
+	if p, ok := Resource[Position](storage); !ok || p.Y != 4 {
+		t.Fatal("removing one resource dropped another")
+	}
+}
+
+type Owns struct{}
+
+func TestRelations(t *testing.T) {
+	storage := New[uint32]()
+	SetCascade[ChildOf](storage, CascadeRemove)
+	SetCascade[Owns](storage, CascadeOrphan)
+	for _, id := range []uint32{1, 2, 3, 4} {
+		Set1(storage, id, Position{})
+	}
+	Relation(storage, 2, 1, ChildOf{})
+	Relation(storage, 3, 1, ChildOf{})
+	Relation(storage, 4, 2, ChildOf{})
+	Set1(storage, 10, Walking{})
+	Relation(storage, 1, 10, Owns{})
+	if target, ok := Target[ChildOf](storage, 4); !ok || target != 2 {
+		t.Fatal("Target did not return the parent")
+	}
+	var children []uint32
+	Query1[Pair[ChildOf, uint32]](storage).Each(func(id uint32, p *Pair[ChildOf, uint32]) {
+		children = append(children, id)
+	}, Q1Option{Hash: RelationTo[ChildOf](storage, 1)})
+	slices.Sort(children)
+	if !slices.Equal(children, []uint32{2, 3}) || !slices.Equal(Related[ChildOf](storage, 2), []uint32{4}) {
+		t.Fatalf("unexpected children of 1: %v", children)
+	}
+	storage.Remove(10)
+	if _, ok := Target[Owns](storage, 1); ok || !Has[Position](storage, 1) {
+		t.Fatal("CascadeOrphan did not just unset the relation")
+	}
+	storage.Remove(1)
+	for _, id := range []uint32{2, 3, 4} {
+		if Has[Position](storage, id) {
+			t.Fatalf("CascadeRemove kept entity %d", id)
+		}
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	storage := New[uint32]()
+	for id := uint32(1); id <= 5; id++ {
+		Set1(storage, id, Position{})
+	}
+	// Deepest first, so compound order alone would visit children before parents
+	for _, link := range [][2]uint32{{5, 4}, {4, 2}, {3, 1}, {2, 1}} {
+		if err := storage.SetParent(link[0], link[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storage.SetParent(1, 5); !errors.Is(err, ErrHierarchyCycle) {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+	if ancestors := storage.Ancestors(5); !slices.Equal(ancestors, []uint32{4, 2, 1}) {
+		t.Fatalf("unexpected ancestors %v", ancestors)
+	}
+	children := storage.Children(1)
+	slices.Sort(children)
+	if !slices.Equal(children, []uint32{2, 3}) {
+		t.Fatalf("unexpected children %v", children)
+	}
+	visited := map[uint32]bool{}
+	Query1[Position](storage).EachHierarchy(func(id uint32, p *Position) {
+		if parent, ok := storage.Parent(id); ok && !visited[parent] {
+			t.Fatalf("visited %d before its parent %d", id, parent)
+		}
+		visited[id] = true
+	})
+	if len(visited) != 5 {
+		t.Fatalf("expected 5 entities, visited %d", len(visited))
+	}
+	storage.RemoveRecursive(2)
+	for id, alive := range map[uint32]bool{1: true, 2: false, 3: true, 4: false, 5: false} {
+		if Has[Position](storage, id) != alive {
+			t.Fatalf("entity %d alive should be %v after RemoveRecursive", id, alive)
+		}
+	}
+}
+
+func TestPrefabs(t *testing.T) {
+	storage := New[uint32]()
+	var added int
+	OnAdd(storage, func(id uint32, w Walking) { added++ })
+	orc := NewPrefab("orc", Value(Position{1, 1}), Value(Walking{2})).Share(Value(Momentum{5, 5}))
+	a, b := uint32(1), uint32(2)
+	Set1(storage, 0, Position{4, 4})
+	Instantiate(storage, a, orc)
+	Instantiate(storage, b, orc, Value(Position{9, 9}), Value(Momentum{7, 7}))
+	Set1(storage, 5, Position{5, 5})
+	if p, ok := Get[Position](storage, 0); !ok || p.X != 4 || !Has[Position](storage, 5) {
+		t.Fatal("Instantiate interfered with caller-chosen IDs")
+	}
+	if p, _ := Get[Position](storage, a); p.X != 1 {
+		t.Fatal("instance did not copy the prefab value")
+	}
+	if p, _ := Get[Position](storage, b); p.X != 9 {
+		t.Fatal("override not applied")
+	}
+	if _, ok := Get[Momentum](storage, a); ok {
+		t.Fatal("shared value copied into the instance")
+	}
+	shared, ok := Shared[Momentum](storage, a)
+	if !ok || shared.HS != 5 {
+		t.Fatal("shared value not read from the prefab")
+	}
+	if m, _ := Shared[Momentum](storage, b); m.HS != 7 {
+		t.Fatal("overridden shared value not stored in the instance")
+	}
+	if added != 2 {
+		t.Fatalf("expected 2 OnAdd calls, got %d", added)
+	}
+	if prefab, ok := storage.Prefab("orc"); !ok || prefab != orc {
+		t.Fatal("Instantiate did not register the prefab")
+	}
+	var instances int
+	Query1[InstanceOf](storage).Each(func(id uint32, i *InstanceOf) { instances++ }, Q1Option{Hash: &ComponentHash{ID: ComponentID[InstanceOf](storage), Hash: InstanceOf{"orc"}.Hash()}})
+	if instances != 2 {
+		t.Fatalf("expected 2 orc instances, got %d", instances)
+	}
+}
+
+func TestCloneTransfer(t *testing.T) {
+	lobby := New[uint32]()
+	match := New[uint32]()
+	Set1(match, 9, Walking{}) // Different component IDs than the lobby
+	Set2(lobby, 1, Position{1, 2}, Team{3})
+	Set1(lobby, 2, Walking{})
+	if !Clone(lobby, 1, 2) {
+		t.Fatal("Clone failed")
+	}
+	if p, ok := Get[Position](lobby, 2); !ok || p.Y != 2 || Has[Walking](lobby, 2) {
+		t.Fatal("Clone did not replace the components of the new ID")
+	}
+	if lobby.Entitys[1].Compound != lobby.Entitys[2].Compound {
+		t.Fatal("Clone lost the component hash")
+	}
+	if !Transfer(lobby, match, 1) {
+		t.Fatal("Transfer failed")
+	}
+	if Has[Position](lobby, 1) {
+		t.Fatal("Transfer kept the entity in the source")
+	}
+	if p, team, ok := Get2[Position, Team](match, 1); !ok || p.X != 1 || team.ID != 3 {
+		t.Fatal("Transfer did not copy the components")
+	}
+	if Transfer(lobby, match, 1) {
+		t.Fatal("Transfer of a missing entity succeeded")
+	}
+	Set1(match, 2, Walking{4})
+	if Transfer(lobby, match, 2) {
+		t.Fatal("Transfer replaced an entity of the destination")
+	}
+	if w, ok := Get[Walking](match, 2); !ok || w.Speed != 4 || !Has[Position](lobby, 2) {
+		t.Fatal("failed Transfer changed an entity")
+	}
+	match.Remove(2)
+	var set int
+	OnSet(lobby, func(id uint32, p Position) { set++ })
+	if !Transfer(lobby, lobby, 2) || set != 0 {
+		t.Fatal("Transfer within one storage changed the entity")
+	}
+	// Opposite Transfers must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(from, to *Storage[uint32]) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				Transfer(from, to, 1)
+				Transfer(from, to, 2)
+			}
+		}([]*Storage[uint32]{lobby, match}[i], []*Storage[uint32]{match, lobby}[i])
+	}
+	wg.Wait()
+}
+
+func TestSaveLoad(t *testing.T) {
+	storage := New[uint32]()
+	a, b, c := storage.Spawn(), storage.Spawn(), storage.Spawn()
+	Set2(storage, a, Position{1, 2}, Team{1})
+	Set2(storage, b, Position{3, 4}, Team{2})
+	Set1(storage, c, Walking{5})
+	storage.Remove(c)
+	storage.SetParent(b, a)
+	SetResource(storage, Momentum{6, 7})
+	var saved bytes.Buffer
+	if err := storage.Save(&saved); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry()
+	Register[Position](registry)
+	Register[Team](registry)
+	Register[Walking](registry)
+	Register[Momentum](registry)
+	Register[Pair[ChildOf, uint32]](registry)
+	loaded, err := Load[uint32](bytes.NewReader(saved.Bytes()), registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resaved bytes.Buffer
+	if err := loaded.Save(&resaved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.String() != resaved.String() {
+		t.Fatalf("snapshot did not round-trip:\n%s\n%s", saved.String(), resaved.String())
+	}
+	if p, team, ok := Get2[Position, Team](loaded, b); !ok || p.Y != 4 || team.ID != 2 || loaded.Entitys[a].Compound == loaded.Entitys[b].Compound {
+		t.Fatal("components or hashes not loaded")
+	}
+	if parent, ok := loaded.Parent(b); !ok || parent != a {
+		t.Fatal("relation not loaded")
+	}
+	if m, ok := Resource[Momentum](loaded); !ok || m.VS != 7 {
+		t.Fatal("resource not loaded")
+	}
+	if loaded.Alive(c) || loaded.Spawn() == c {
+		t.Fatal("allocator state not loaded")
+	}
+	type Position struct{ X, Y int }
+	Register[Position](registry)
+	if _, err := Load[uint32](bytes.NewReader(saved.Bytes()), registry); !errors.Is(err, ErrComponentAmbiguous) {
+		t.Fatalf("expected an ambiguous component error, got %v", err)
+	}
+	if _, err := Load[uint32](bytes.NewReader(saved.Bytes()), NewRegistry()); !errors.Is(err, ErrComponentUnknown) {
+		t.Fatalf("expected an unknown component error, got %v", err)
+	}
+	for _, corrupt := range []string{
+		`{"Compounds":[{"Entitys":[1,1]}]}`,
+		`{"Allocator":{"Used":true,"Generations":[0,0],"Alive":[true]}}`,
+		`{"Allocator":{"Used":true,"Generations":[0],"Alive":[false],"Free":[1]}}`,
+		`{"Allocator":{"Used":true,"Generations":[0],"Alive":[true],"Free":[0]}}`,
+	} {
+		if _, err := Load[uint32](strings.NewReader(corrupt), NewRegistry()); !errors.Is(err, ErrSnapshotCorrupt) {
+			t.Fatalf("expected a corrupt snapshot error for %s, got %v", corrupt, err)
+		}
+	}
+}
+
+func TestRemoveRecursiveCascades(t *testing.T) {
+	for _, cascade := range []Cascade{CascadeKeep, CascadeOrphan, CascadeRemove} {
+		storage := New[uint32]()
+		SetCascade[ChildOf](storage, cascade)
+		for id := uint32(1); id <= 4; id++ {
+			Set1(storage, id, Position{})
+		}
+		storage.SetParent(2, 1)
+		storage.SetParent(3, 2)
+		storage.RemoveRecursive(1)
+		for id := uint32(1); id <= 3; id++ {
+			if Has[Position](storage, id) {
+				t.Fatalf("cascade %d kept entity %d", cascade, id)
+			}
+		}
+		if !Has[Position](storage, 4) {
+			t.Fatalf("cascade %d removed an unrelated entity", cascade)
+		}
+	}
+}
+
 func BenchmarkPut1m(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		b.StopTimer()
@@ -64,6 +988,77 @@ func Benchmark1mMove(b *testing.B) {
 	}
 }
 
+func BenchmarkPut1mReserved(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		storage := New[uint32](WithEntities(1_000_000))
+		Reserve1[Position](storage, 1_000_000)
+		var id uint32
+		var v Position
+		b.StartTimer()
+		for i := 0; i < 1_000_000; i++ {
+			Set1(storage, id, v)
+			id++
+		}
+	}
+}
+
+func BenchmarkPutBatch1m(b *testing.B) {
+	ids := make([]uint32, 1_000_000)
+	for i := range ids {
+		ids[i] = uint32(i)
+	}
+	values := make([]Position, len(ids))
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		storage := New[uint32]()
+		b.StartTimer()
+		SetBatch1(storage, ids, values)
+	}
+}
+
+func benchmarkSetGet(b *testing.B, storage *Storage[uint32]) {
+	for i := uint32(0); i < 1000; i++ {
+		Set1(storage, i, Position{})
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		id := uint32(n % 1000)
+		p, _ := Get[Position](storage, id)
+		Set1(storage, id, Position{p.X + 1, p.Y})
+	}
+}
+
+func BenchmarkSetGetLocked(b *testing.B) {
+	benchmarkSetGet(b, New[uint32]())
+}
+
+func BenchmarkSetGetUnlocked(b *testing.B) {
+	benchmarkSetGet(b, New[uint32](WithoutLocks()))
+}
+
+func benchmarkSmallQuery(b *testing.B, storage *Storage[uint32]) {
+	for i := uint32(0); i < 10; i++ {
+		Set2(storage, i, Position{}, Momentum{1, 1})
+	}
+	q := Query2[Position, Momentum](storage)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		q.Each(func(id uint32, p *Position, m *Momentum) {
+			p.X += m.HS
+			p.Y += m.VS
+		})
+	}
+}
+
+func BenchmarkSmallQueryLocked(b *testing.B) {
+	benchmarkSmallQuery(b, New[uint32]())
+}
+
+func BenchmarkSmallQueryUnlocked(b *testing.B) {
+	benchmarkSmallQuery(b, New[uint32](WithoutLocks()))
+}
+
 // BenchmarkBouncing-8   	     206	   5323178 ns/op	       0 B/op	       0 allocs/op
 
 func BenchmarkBouncing(b *testing.B) {
@@ -137,6 +1132,38 @@ func TestBasicQuery(t *testing.T) {
 	t.Log(string(js))
 }
 
+func Benchmark1mMoveParallel(b *testing.B) {
+	storage := New[uint32]()
+	for i := 0; i < 1_000_000; i++ {
+		Set1(storage, uint32(i), Position{100, 200})
+	}
+	q := Query1[Position](storage)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		q.ParEach(nil, func(id uint32, p *Position) {
+			p.X++
+			p.Y++
+		})
+	}
+}
+
+func Benchmark1mMoveChunk(b *testing.B) {
+	storage := New[uint32]()
+	for i := 0; i < 1_000_000; i++ {
+		Set1(storage, uint32(i), Position{100, 200})
+	}
+	q := Query1[Position](storage)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		q.EachChunk(func(_ []uint32, ps []Position) {
+			for i := range ps {
+				ps[i].X++
+				ps[i].Y++
+			}
+		})
+	}
+}
+
 func Benchmark1mMoveQuery(b *testing.B) {
 	storage := New[uint32]()
 	for i := 0; i < 1_000_000; i++ {
@@ -306,7 +1333,7 @@ func Benchmark1000x10MapData(b *testing.B) {
 			target = m[r]
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark1000x10MapTarget(b *testing.B) {
@@ -331,7 +1358,7 @@ func Benchmark1000x10MapTarget(b *testing.B) {
 			}
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark10000x10Array(b *testing.B) {
@@ -375,7 +1402,7 @@ func Benchmark10000x10MapData(b *testing.B) {
 			target = m[r]
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark10000x10MapTarget(b *testing.B) {
@@ -400,7 +1427,7 @@ func Benchmark10000x10MapTarget(b *testing.B) {
 			}
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark10000x100Array(b *testing.B) {
@@ -444,7 +1471,7 @@ func Benchmark10000x100MapData(b *testing.B) {
 			target = m[r]
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark10000x100MapTarget(b *testing.B) {
@@ -469,7 +1496,7 @@ func Benchmark10000x100MapTarget(b *testing.B) {
 			}
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
 
 func Benchmark1x1MapTarget(b *testing.B) {
@@ -494,5 +1521,5 @@ func Benchmark1x1MapTarget(b *testing.B) {
 			}
 		}
 	}
-	fmt.Sprint(target)
+	_ = fmt.Sprint(target)
 }
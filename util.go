@@ -1,63 +1,254 @@
 package ecs
 
 import (
-	"cmp"
+	"fmt"
 	"reflect"
 	"slices"
 )
 
-func (storage *Storage[ID]) componentEnsure(v any) int {
-	name := reflect.TypeOf(v).String()
-	for idx, v := range storage.Components {
-		if v.Name == name {
-			return idx
-		}
+func componentEnsure[T any, ID Int](storage *Storage[ID]) int {
+	return storage.componentEnsureType(typeOf[T]())
+}
+
+func (storage *Storage[ID]) componentEnsureType(t reflect.Type) int {
+	if idx, ok := storage.componentTypes[t]; ok {
+		return idx
 	}
-	storage.Components = append(storage.Components, Component{Name: name})
+	if storage.componentTypes == nil {
+		storage.componentTypes = map[reflect.Type]int{}
+	}
+	storage.Components = append(storage.Components, Component{Name: t.String(), Type: t, hashable: t.Implements(hashableType)})
+	storage.componentTypes[t] = len(storage.Components) - 1
 	return len(storage.Components) - 1
 }
 
 func (storage *Storage[ID]) compoundEnsure(components, hashes []int) int {
-CompoundLoop:
-	for idx, v := range storage.Compounds {
-		if len(v.Components) != len(components) {
-			continue
-		}
-	EqualityLoop:
-		for ai, av := range components {
-			for _, b := range v.Components {
-				if av != b.ID {
-					continue
-				}
-				if hashes[ai] != b.Hash {
-					continue CompoundLoop
-				}
-				continue EqualityLoop
-			}
-			continue CompoundLoop
+	key := compoundKey(components, hashes)
+	for _, idx := range storage.compoundIndex[key] {
+		if compoundEqual(storage.Compounds[idx].Components, components, hashes) {
+			return idx
 		}
-		return idx
 	}
-	var cmps []CompoundComponent
+	cmps := make([]CompoundComponent, len(components))
 	for idx, component := range components {
-		cmps = append(cmps, CompoundComponent{ID: component, Hash: hashes[idx]})
+		cmps[idx] = CompoundComponent{ID: component, Hash: hashes[idx]}
 	}
 	storage.Compounds = append(storage.Compounds, &Compound[ID]{Components: cmps})
+	if storage.compoundIndex == nil {
+		storage.compoundIndex = map[uint64][]int{}
+	}
+	storage.compoundIndex[key] = append(storage.compoundIndex[key], len(storage.Compounds)-1)
 	return len(storage.Compounds) - 1
 }
 
-func (storage *Storage[ID]) getComponent(name string) (int, bool) {
+// compoundKey hashes a set of components and their hashes independent of their order.
+func compoundKey(components, hashes []int) uint64 {
+	key := uint64(len(components))
+	for idx, component := range components {
+		key += mix(uint64(component)<<32 ^ uint64(hashes[idx]))
+	}
+	return key
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+func compoundEqual(cmps []CompoundComponent, components, hashes []int) bool {
+	if len(cmps) != len(components) {
+		return false
+	}
+EqualityLoop:
+	for ai, av := range components {
+		for _, b := range cmps {
+			if av != b.ID {
+				continue
+			}
+			if hashes[ai] != b.Hash {
+				return false
+			}
+			continue EqualityLoop
+		}
+		return false
+	}
+	return true
+}
+
+// compoundClean drops the rows of removed entities from a compound.
+func (storage *Storage[ID]) compoundClean(compoundIdx int) {
+	compound := storage.Compounds[compoundIdx]
+	if compound.Removed == nil {
+		return
+	}
+	rows := make([]int, 0, compound.RemovedCount)
+	for idx, removed := range compound.Removed {
+		if removed {
+			rows = append(rows, idx)
+		}
+	}
+	storage.compoundPurge(compoundIdx, rows)
+	compound.Removed, compound.RemovedCount = nil, 0
+}
+
+// appendRow adds a live row for an entity and returns its index.
+func (compound *Compound[ID]) appendRow(id ID) int {
+	compound.Entitys = append(compound.Entitys, id)
+	if compound.Removed != nil {
+		compound.Removed = append(compound.Removed, false)
+	}
+	return len(compound.Entitys) - 1
+}
+
+// growRows makes room for n more rows without reallocating.
+func (compound *Compound[ID]) growRows(n int) {
+	compound.Entitys = slices.Grow(compound.Entitys, n)
+	if compound.Removed != nil {
+		compound.Removed = slices.Grow(compound.Removed, n)
+	}
+}
+
+func (compound *Compound[ID]) rowRemoved(row int) bool {
+	return compound.Removed != nil && compound.Removed[row]
+}
+
+// compoundRemoveRows removes the given rows, in ascending order, from a compound.
+func (storage *Storage[ID]) compoundRemoveRows(compoundIdx int, rows []int) {
+	for i := len(rows) - 1; i >= 0; i-- {
+		storage.compoundRemoveRow(compoundIdx, rows[i])
+	}
+}
+
+// compoundRemoveRow swap-removes a row and updates the Row of the entity moved into its place.
+func (storage *Storage[ID]) compoundRemoveRow(compoundIdx, row int) {
+	compound := storage.Compounds[compoundIdx]
+	last := len(compound.Entitys) - 1
+	compound.Entitys = sliceRemove(compound.Entitys, row)
+	if compound.Removed != nil {
+		if compound.Removed[row] {
+			compound.RemovedCount--
+		}
+		compound.Removed = sliceRemove(compound.Removed, row)
+	}
+	for idx := range compound.Components {
+		component := &compound.Components[idx]
+		component.Data.remove(row)
+		component.Added = sliceRemove(component.Added, row)
+		component.Changed = sliceRemove(component.Changed, row)
+	}
+	if row == last {
+		return
+	}
+	moved := compound.Entitys[row]
+	if entity, ok := storage.Entitys[moved]; ok && entity.Compound == compoundIdx && entity.Row == last {
+		entity.Row = row
+		storage.Entitys[moved] = entity
+	}
+}
+
+// entityMove moves an entity into another compound, copying every column both compounds share
+// except skip. The caller appends the columns that only exist in the destination.
+func (storage *Storage[ID]) entityMove(id ID, entity Entity, compoundIdx, skip int) Entity {
+	src := storage.Compounds[entity.Compound]
+	dst := storage.Compounds[compoundIdx]
+	for idx := range dst.Components {
+		column := &dst.Components[idx]
+		if column.ID == skip {
+			continue
+		}
+		from, ok := compoundColumn(src, column.ID)
+		if !ok {
+			continue
+		}
+		if column.Data == nil {
+			column.Data = src.Components[from].Data.empty()
+		}
+		column.Data.appendFrom(src.Components[from].Data, entity.Row)
+		column.Added = append(column.Added, src.Components[from].Added[entity.Row])
+		column.Changed = append(column.Changed, src.Components[from].Changed[entity.Row])
+	}
+	storage.compoundRemoveRow(entity.Compound, entity.Row)
+	moved := Entity{Compound: compoundIdx, Row: dst.appendRow(id)}
+	storage.Entitys[id] = moved
+	return moved
+}
+
+// compoundSet overwrites the value of a component at row and stamps it as changed.
+func compoundSet[T any, ID Int](compound *Compound[ID], component, row int, v T, tick uint64) {
+	idx, _ := compoundColumn(compound, component)
+	compound.Components[idx].Data.(*slice[T]).Data[row] = v
+	compound.Components[idx].Changed[row] = tick
+}
+
+// compoundAppend appends a value to the column of a component, creating the column if needed.
+// added is the tick the entity got the component, tick the one it is written at.
+func compoundAppend[T any, ID Int](compound *Compound[ID], component int, v T, added, tick uint64) {
+	idx, _ := compoundColumn(compound, component)
+	column := &compound.Components[idx]
+	if column.Data == nil {
+		column.Data = &slice[T]{}
+	}
+	column.Data.(*slice[T]).append(v)
+	column.Added = append(column.Added, added)
+	column.Changed = append(column.Changed, tick)
+}
+
+// compoundGrow makes room for n more values in the column of a component, creating it if needed.
+func compoundGrow[T any, ID Int](compound *Compound[ID], component int, n int) {
+	idx, _ := compoundColumn(compound, component)
+	column := &compound.Components[idx]
+	if column.Data == nil {
+		column.Data = &slice[T]{}
+	}
+	data := column.Data.(*slice[T])
+	data.Data = slices.Grow(data.Data, n)
+	column.Added = slices.Grow(column.Added, n)
+	column.Changed = slices.Grow(column.Changed, n)
+}
+
+// rowAdded copies the Added tick of the components an entity's row already has into added.
+func (storage *Storage[ID]) rowAdded(entity Entity, components []int, added []uint64) {
+	for _, column := range storage.Compounds[entity.Compound].Components {
+		if idx, ok := sliceFind(components, column.ID); ok {
+			added[idx] = column.Added[entity.Row]
+		}
+	}
+}
+
+func compoundColumn[ID Int](compound *Compound[ID], component int) (int, bool) {
+	return componentsColumn(compound.Components, component)
+}
+
+func (storage *Storage[ID]) getComponent(t reflect.Type) (int, bool) {
+	id, ok := storage.componentTypes[t]
+	return id, ok
+}
+
+// componentByName finds a component by its Name. Names are only unique per package, so a name
+// shared by several registered types is reported as ambiguous.
+func (storage *Storage[ID]) componentByName(name string) (int, error) {
+	found, matches := 0, 0
 	for id, cmp := range storage.Components {
 		if cmp.Name == name {
-			return id, true
+			found = id
+			matches++
 		}
 	}
-	return 0, false
+	switch matches {
+	case 0:
+		return 0, fmt.Errorf("%w: %s", ErrComponentUnknown, name)
+	case 1:
+		return found, nil
+	}
+	return 0, fmt.Errorf("%w: %s matches %d types", ErrComponentAmbiguous, name, matches)
 }
 
-func typeName[T any]() string {
-	var z [0]T
-	return reflect.TypeOf(z).Elem().String()
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
 }
 
 func sliceRemove[V any](s []V, i int) []V {
@@ -65,6 +256,16 @@ func sliceRemove[V any](s []V, i int) []V {
 	return s[:len(s)-1]
 }
 
+// sliceShrink copies s into a slice with no spare capacity.
+func sliceShrink[V any](s []V) []V {
+	if cap(s) == len(s) {
+		return s
+	}
+	shrunk := make([]V, len(s))
+	copy(shrunk, s)
+	return shrunk
+}
+
 func getOptional[T any](slice []T, idx int) *T {
 	if slice == nil {
 		return nil
@@ -72,20 +273,23 @@ func getOptional[T any](slice []T, idx int) *T {
 	return &slice[idx]
 }
 
-func sliceInsertOrdered[T cmp.Ordered](ts []T, t T) []T {
-	i, _ := slices.BinarySearch(ts, t) // find slot
-	// Make room for new value and add it
-	ts = append(ts, *new(T))
-	copy(ts[i+1:], ts[i:])
-	ts[i] = t
-	return ts
+func (storage *Storage[ID]) componentsHashable(components []int) bool {
+	for _, component := range components {
+		if storage.Components[component].hashable {
+			return true
+		}
+	}
+	return false
 }
 
-func componentHash(v any) int {
-	if h, ok := v.(Hashable); ok {
-		return h.Hash()
+var hashableType = reflect.TypeFor[Hashable]()
+
+// componentHash returns the Hash of v, only boxing it when T implements Hashable.
+func componentHash[T any, ID Int](storage *Storage[ID], component int, v T) int {
+	if !storage.Components[component].hashable {
+		return 0
 	}
-	return 0
+	return any(v).(Hashable).Hash()
 }
 
 func sliceFind[V comparable](slice []V, value V) (int, bool) {